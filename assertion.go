@@ -0,0 +1,13 @@
+package errors
+
+import "github.com/upfluence/errors/assertion"
+
+// AssertionFailed returns an error flagging a broken invariant, with a
+// formatted message and the full stack trace of the call site. It is always
+// reported at the fatal level and never inhibited, see the assertion package.
+func AssertionFailed(format string, args ...interface{}) error {
+	return assertion.Failed(1, format, args...)
+}
+
+// IsAssertionFailure reports whether err is, or wraps, an assertion failure.
+func IsAssertionFailure(err error) bool { return assertion.Is(err) }
@@ -0,0 +1,87 @@
+// Package assertion provides errors flagging broken invariants, states the
+// program should never reach.
+//
+// Assertion failures always carry a full stack trace, are reported at the
+// fatal level and are never inhibited. Building with the errors_assert_panic
+// tag, or running with the ERRORS_ASSERT_PANIC environment variable set to a
+// non-empty value, turns them into panics, which is handy in tests.
+package assertion
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/domain"
+	"github.com/upfluence/errors/internal/logvalue"
+	"github.com/upfluence/errors/stacktrace"
+	"github.com/upfluence/errors/value"
+)
+
+// MaxFrames is the maximum number of frames captured by Failed.
+const MaxFrames = 64
+
+var panicOnFailure = panicTag || os.Getenv("ERRORS_ASSERT_PANIC") != ""
+
+type failure struct {
+	cause error
+}
+
+func (f *failure) Error() string          { return "assertion failed: " + f.cause.Error() }
+func (f *failure) Unwrap() error          { return f.cause }
+func (f *failure) Cause() error           { return f.cause }
+func (f *failure) AssertionFailure() bool { return true }
+
+func (f *failure) Format(s fmt.State, verb rune) { base.FormatError(f, s, verb) }
+func (f *failure) LogValue() slog.Value          { return logvalue.Of(f, stacktrace.Origin(f)) }
+
+func (f *failure) FormatError(p base.Printer) error {
+	p.Printf("assertion failed")
+	return f.cause
+}
+
+// Failed returns an assertion failure with a formatted message, the stack
+// trace is captured at the specified depth (0 = the caller of Failed).
+// Panics with the failure instead if panics are enabled.
+func Failed(depth int, format string, args ...interface{}) error {
+	err := &failure{
+		cause: stacktrace.WithStacktrace(
+			domain.WithDomain(
+				fmt.Errorf(format, args...),
+				domain.PackageDomainAtDepth(depth+1),
+			),
+			depth+1,
+			MaxFrames,
+		),
+	}
+
+	if panicOnFailure {
+		panic(err)
+	}
+
+	return err
+}
+
+type failureKey struct{}
+
+func (failureKey) Resolve(err error) (interface{}, bool) { return nil, Is(err) }
+
+// Is reports whether any error of the tree of err is an assertion failure.
+// Errors hiding their chain, like opaque errors, expose the assertion
+// failures of their cause.
+func Is(err error) bool {
+	var found bool
+
+	base.Inspect(err, func(err error) bool {
+		if aerr, ok := err.(interface{ AssertionFailure() bool }); ok {
+			found = aerr.AssertionFailure()
+		} else if _, ok := value.Own(err, failureKey{}); ok {
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
@@ -0,0 +1,49 @@
+package assertion_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/assertion"
+	"github.com/upfluence/errors/domain"
+	"github.com/upfluence/errors/stacktrace"
+)
+
+func TestFailed(t *testing.T) {
+	err := errors.AssertionFailed("unexpected state %q", "foo")
+
+	assert.Equal(t, `assertion failed: unexpected state "foo"`, err.Error())
+	assert.Equal(t, domain.Domain("github.com/upfluence/errors/assertion_test"), domain.GetDomain(err))
+
+	fs := stacktrace.GetFrames(err)
+
+	assert.Greater(t, len(fs), 1)
+
+	fn, _, _ := fs[0].Location()
+
+	assert.Equal(t, "github.com/upfluence/errors/assertion_test.TestFailed", fn)
+	assert.Contains(t, fmt.Sprintf("%+v", err), "assertion failed\n")
+}
+
+func TestIs(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil"},
+		{name: "regular", err: errors.New("foo")},
+		{name: "failure", err: assertion.Failed(0, "foo"), want: true},
+		{name: "wrapped", err: errors.Wrap(assertion.Failed(0, "foo"), "bar"), want: true},
+		{name: "opaque", err: errors.Opaque(assertion.Failed(0, "foo")), want: true},
+		{name: "multi", err: errors.Combine(io.EOF, assertion.Failed(0, "foo")), want: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errors.IsAssertionFailure(tt.err))
+		})
+	}
+}
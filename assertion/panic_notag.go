@@ -0,0 +1,5 @@
+//go:build !errors_assert_panic
+
+package assertion
+
+const panicTag = false
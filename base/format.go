@@ -0,0 +1,92 @@
+package base
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Printer is handed to a Formatter when an error is rendered with the %+v
+// verb. Every line written through it is indented under the layer being
+// described.
+type Printer interface {
+	// Printf writes a detail line for the current layer.
+	Printf(format string, args ...interface{})
+
+	// Branch renders err, and every layer below it, as a nested tree under
+	// the current layer. It is used by errors holding more than one cause.
+	Branch(name string, err error)
+}
+
+// Formatter is implemented by errors able to describe their own layer in
+// the verbose %+v rendering. FormatError writes the details of the layer on
+// p and returns the next error of the chain, or nil when the chain stops.
+type Formatter interface {
+	FormatError(p Printer) (next error)
+}
+
+// FormatError is the fmt.Formatter implementation shared by the wrappers of
+// this module. Every verb but %+v renders err.Error() exactly as the fmt
+// package would. %+v renders the message followed by a dump of every layer
+// of the error chain.
+func FormatError(err error, s fmt.State, verb rune) {
+	if verb != 'v' || !s.Flag('+') {
+		fmt.Fprintf(s, fmt.FormatString(s, verb), err.Error())
+		return
+	}
+
+	io.WriteString(s, err.Error())
+
+	p := printer{w: s, indent: 2}
+	p.chain(err)
+}
+
+type printer struct {
+	w      io.Writer
+	indent int
+}
+
+func (p *printer) line(s string) {
+	io.WriteString(p.w, "\n")
+	io.WriteString(p.w, strings.Repeat(" ", p.indent))
+	io.WriteString(p.w, s)
+}
+
+func (p *printer) Printf(format string, args ...interface{}) {
+	for _, l := range strings.Split(fmt.Sprintf(format, args...), "\n") {
+		p.line(l)
+	}
+}
+
+func (p *printer) Branch(name string, err error) {
+	if err == nil {
+		return
+	}
+
+	p.line(name + ": " + err.Error())
+
+	bp := printer{w: p.w, indent: p.indent + 2}
+	bp.chain(err)
+}
+
+func (p *printer) chain(err error) {
+	for err != nil {
+		p.line(fmt.Sprintf("- %T", err))
+
+		lp := printer{w: p.w, indent: p.indent + 4}
+
+		switch e := err.(type) {
+		case Formatter:
+			err = e.FormatError(&lp)
+		case interface{ Unwrap() []error }:
+			for i, cerr := range e.Unwrap() {
+				lp.Branch(fmt.Sprintf("[%d]", i), cerr)
+			}
+
+			err = nil
+		default:
+			lp.Printf("%s", err.Error())
+			err = UnwrapOnce(err)
+		}
+	}
+}
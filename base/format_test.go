@@ -0,0 +1,77 @@
+package base_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+)
+
+func TestFormatError(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+	}{
+		{name: "sentinel", err: errors.New("foo")},
+		{name: "wrapped", err: errors.Wrapf(errors.New("foo"), "bar %d", 1)},
+		{
+			name: "tags & status",
+			err: errors.WithStatus(
+				errors.WithTags(errors.New("foo"), map[string]interface{}{"a": 1}),
+				"baz",
+			),
+		},
+		{name: "multi", err: errors.Combine(errors.New("foo"), errors.New("bar"))},
+		{
+			name: "secondary",
+			err:  errors.WithSecondaryError(errors.New("foo"), errors.New("bar")),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, verb := range []string{"%v", "%s", "%q", "%x", "%10.3v", "%-8s"} {
+				assert.Equal(
+					t,
+					fmt.Sprintf(verb, tt.err.Error()),
+					fmt.Sprintf(verb, tt.err),
+				)
+			}
+
+			out := fmt.Sprintf("%+v", tt.err)
+
+			assert.True(t, strings.HasPrefix(out, tt.err.Error()+"\n"))
+			assert.Contains(t, out, "github.com/upfluence/errors/base_test.TestFormatError")
+			assert.Contains(t, out, "format_test.go:")
+		})
+	}
+}
+
+func TestFormatErrorDetails(t *testing.T) {
+	err := errors.WithSecondaryError(
+		errors.WithStatus(
+			errors.WithTags(
+				errors.Wrapf(errors.New("foo"), "bar %d", 1),
+				map[string]interface{}{"b": "x", "a": 1},
+			),
+			"baz",
+		),
+		errors.Combine(errors.New("biz"), fmt.Errorf("buz: %w", errors.New("qux"))),
+	)
+
+	out := fmt.Sprintf("%+v", err)
+
+	for _, want := range []string{
+		"\n  - *secondary.withSecondary\n      secondary: [biz, buz: qux]\n",
+		"\n        - multi.multiError\n            [0]: biz\n",
+		"\n            [1]: buz: qux\n              - *fmt.wrapError\n                  buz: qux\n",
+		"\n  - *stats.withStatus\n      status: baz\n",
+		"\n  - *tags.withTags\n      tags: a=1, b=x\n",
+		"\n  - *message.withMessage\n      bar 1\n",
+		"\n  - *domain.withDomain\n      domain: github.com/upfluence/errors/base_test\n",
+		"\n  - *errors.errorString\n      foo",
+	} {
+		assert.Contains(t, out, want)
+	}
+}
@@ -1,5 +1,8 @@
 package base
 
+// UnwrapOnce returns the next error of the chain of err, through Cause() or
+// Unwrap() error. Returns nil if err has no next error, errors holding
+// several causes through Unwrap() []error have none.
 func UnwrapOnce(err error) error {
 	switch e := err.(type) {
 	case interface{ Cause() error }:
@@ -11,10 +14,36 @@ func UnwrapOnce(err error) error {
 	return nil
 }
 
+// UnwrapFirst is UnwrapOnce, but it follows the first branch of the errors
+// implementing Unwrap() []error.
+func UnwrapFirst(err error) error {
+	if next := UnwrapOnce(err); next != nil {
+		return next
+	}
+
+	if merr, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, cerr := range merr.Unwrap() {
+			if cerr != nil {
+				return cerr
+			}
+		}
+	}
+
+	return nil
+}
+
+// UnwrapAll returns the root cause of err. The errors implementing
+// Unwrap() []error are followed only if they hold a single error, otherwise
+// they have no single root cause and they are returned as is. Root follows
+// their first branch instead.
 func UnwrapAll(err error) error {
 	for {
 		cause := UnwrapOnce(err)
 
+		if cause == nil {
+			cause = singleBranch(err)
+		}
+
 		if cause == nil {
 			break
 		}
@@ -24,3 +53,17 @@ func UnwrapAll(err error) error {
 
 	return err
 }
+
+func singleBranch(err error) error {
+	merr, ok := err.(interface{ Unwrap() []error })
+
+	if !ok {
+		return nil
+	}
+
+	if errs := merr.Unwrap(); len(errs) == 1 {
+		return errs[0]
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package base
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MaxWalkDepth bounds the length of the paths visited by Walk. The nodes
+// deeper in the tree are not visited.
+const MaxWalkDepth = 256
+
+// StepKind tells how a node of an error tree is reached from its parent.
+type StepKind uint8
+
+const (
+	// ChainStep goes through Cause() error or Unwrap() error.
+	ChainStep StepKind = iota
+
+	// MultiStep goes through the branch Index of Unwrap() []error.
+	MultiStep
+
+	// SecondaryStep goes through SecondaryError() error.
+	SecondaryStep
+)
+
+// Step is one edge of a Path.
+type Step struct {
+	Kind StepKind
+
+	// Index is the branch index of a MultiStep, 0 otherwise.
+	Index int
+}
+
+func (s Step) String() string {
+	switch s.Kind {
+	case MultiStep:
+		return "[" + strconv.Itoa(s.Index) + "]"
+	case SecondaryStep:
+		return "secondary"
+	}
+
+	return "cause"
+}
+
+// Path is the list of steps leading from the root of an error tree to a
+// node. The root itself has an empty path.
+type Path []Step
+
+func (p Path) String() string {
+	var b strings.Builder
+
+	for _, s := range p {
+		b.WriteRune('/')
+		b.WriteString(s.String())
+	}
+
+	if b.Len() == 0 {
+		return "/"
+	}
+
+	return b.String()
+}
+
+// WalkAction tells Walk how to proceed after visiting a node.
+type WalkAction uint8
+
+const (
+	// WalkContinue visits the children of the node, then its siblings.
+	WalkContinue WalkAction = iota
+
+	// WalkSkip does not visit the children of the node.
+	WalkSkip
+
+	// WalkStop ends the walk.
+	WalkStop
+)
+
+// Walk visits every node of the tree of err depth first, parents before
+// their children. The children of a node are visited in this order: the
+// next error of the chain, the branches of Unwrap() []error by index, and
+// finally the secondary error.
+//
+// A node already present in its own path is not visited again, so cyclic
+// trees are walked once, and the walk stops descending at MaxWalkDepth.
+func Walk(err error, fn func(error, Path) WalkAction) {
+	if err == nil {
+		return
+	}
+
+	walk(err, nil, nil, fn)
+}
+
+func walk(err error, path Path, ancestors []error, fn func(error, Path) WalkAction) bool {
+	switch fn(err, path) {
+	case WalkStop:
+		return false
+	case WalkSkip:
+		return true
+	}
+
+	if len(path) >= MaxWalkDepth {
+		return true
+	}
+
+	ancestors = append(ancestors, err)
+
+	visit := func(cerr error, s Step) bool {
+		if cerr == nil || isAncestor(cerr, ancestors) {
+			return true
+		}
+
+		return walk(cerr, append(path[:len(path):len(path)], s), ancestors, fn)
+	}
+
+	if !visit(UnwrapOnce(err), Step{Kind: ChainStep}) {
+		return false
+	}
+
+	if merr, ok := err.(interface{ Unwrap() []error }); ok {
+		for i, cerr := range merr.Unwrap() {
+			if !visit(cerr, Step{Kind: MultiStep, Index: i}) {
+				return false
+			}
+		}
+	}
+
+	if serr, ok := err.(interface{ SecondaryError() error }); ok {
+		return visit(serr.SecondaryError(), Step{Kind: SecondaryStep})
+	}
+
+	return true
+}
+
+func isAncestor(err error, ancestors []error) bool {
+	// only the errors held by pointer can close a cycle, comparing the
+	// others may panic on the non comparable values of their fields
+	v := reflect.ValueOf(err)
+
+	if v.Kind() != reflect.Pointer {
+		return false
+	}
+
+	for _, a := range ancestors {
+		if av := reflect.ValueOf(a); av.Type() == v.Type() && av.Pointer() == v.Pointer() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Inspect calls fn on every node of the tree of err in the order of Walk,
+// until fn returns false. The secondary errors, and their trees, are not
+// visited.
+//
+// It defines the precedence rule of every helper of the module: the first
+// node visited wins. A value is taken from the first node carrying it, so
+// outer errors take precedence over inner ones and the branches of a multi
+// error take precedence in index order. The call path of an error, its
+// frames, operations, templates and root cause, is the path leading to the
+// first leaf visited, see InspectPath.
+func Inspect(err error, fn func(error) bool) {
+	Walk(err, func(err error, p Path) WalkAction {
+		if len(p) > 0 && p[len(p)-1].Kind == SecondaryStep {
+			return WalkSkip
+		}
+
+		if !fn(err) {
+			return WalkStop
+		}
+
+		return WalkContinue
+	})
+}
+
+// InspectPath calls fn on the nodes of the first path of the tree of err,
+// until fn returns false: the nodes visited by Inspect down to the first
+// leaf, that is the chain of err following the first branch of every multi
+// error.
+func InspectPath(err error, fn func(error) bool) {
+	Inspect(err, func(err error) bool { return fn(err) && UnwrapFirst(err) != nil })
+}
+
+// Root returns the last node of the first path of the tree of err, see
+// InspectPath. Unlike UnwrapAll, it follows the first branch of the multi
+// errors. Returns nil if err is nil.
+func Root(err error) error {
+	var root error
+
+	InspectPath(err, func(err error) bool {
+		root = err
+		return true
+	})
+
+	return root
+}
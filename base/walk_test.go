@@ -0,0 +1,218 @@
+package base_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/multi"
+	"github.com/upfluence/errors/secondary"
+)
+
+type cyclicError struct{ next error }
+
+func (e *cyclicError) Error() string { return "cyclic" }
+func (e *cyclicError) Unwrap() error { return e.next }
+
+type wrapError struct{ err error }
+
+func (e wrapError) Error() string { return "wrap: " + e.err.Error() }
+func (e wrapError) Unwrap() error { return e.err }
+
+type deepError struct{ depth int }
+
+func (e deepError) Error() string { return "deep" }
+func (e deepError) Unwrap() error { return deepError{depth: e.depth + 1} }
+
+func walkPaths(err error, fn func(error, base.Path) base.WalkAction) []string {
+	var res []string
+
+	base.Walk(err, func(err error, p base.Path) base.WalkAction {
+		res = append(res, fmt.Sprintf("%s=%v", p, err))
+
+		if fn == nil {
+			return base.WalkContinue
+		}
+
+		return fn(err, p)
+	})
+
+	return res
+}
+
+func TestWalk(t *testing.T) {
+	cerr := &cyclicError{}
+	cerr.next = &cyclicError{next: cerr}
+
+	for _, tt := range []struct {
+		name string
+		err  error
+		fn   func(error, base.Path) base.WalkAction
+
+		want []string
+	}{
+		{name: "nil"},
+		{name: "leaf", err: io.EOF, want: []string{"/=EOF"}},
+		{
+			name: "chain",
+			err:  fmt.Errorf("foo: %w", io.EOF),
+			want: []string{"/=foo: EOF", "/cause=EOF"},
+		},
+		{
+			name: "multi",
+			err:  fmt.Errorf("%w, %w", io.EOF, multi.Combine(io.ErrClosedPipe, io.ErrNoProgress)),
+			want: []string{
+				"/=EOF, [io: read/write on closed pipe, multiple Read calls return no data or error]",
+				"/[0]=EOF",
+				"/[1]=[io: read/write on closed pipe, multiple Read calls return no data or error]",
+				"/[1]/[0]=io: read/write on closed pipe",
+				"/[1]/[1]=multiple Read calls return no data or error",
+			},
+		},
+		{
+			name: "comparable types holding multi errors",
+			err: wrapError{
+				multi.Combine(io.EOF, errors.Join(wrapError{multi.Combine(io.ErrClosedPipe, io.EOF)}, io.EOF)),
+			},
+			want: []string{
+				"/=wrap: [EOF, wrap: [io: read/write on closed pipe, EOF]\nEOF]",
+				"/cause=[EOF, wrap: [io: read/write on closed pipe, EOF]\nEOF]",
+				"/cause/[0]=EOF",
+				"/cause/[1]=wrap: [io: read/write on closed pipe, EOF]\nEOF",
+				"/cause/[1]/[0]=wrap: [io: read/write on closed pipe, EOF]",
+				"/cause/[1]/[0]/cause=[io: read/write on closed pipe, EOF]",
+				"/cause/[1]/[0]/cause/[0]=io: read/write on closed pipe",
+				"/cause/[1]/[0]/cause/[1]=EOF",
+				"/cause/[1]/[1]=EOF",
+			},
+		},
+		{
+			name: "secondary",
+			err:  secondary.WithSecondaryError(io.EOF, fmt.Errorf("foo: %w", io.ErrClosedPipe)),
+			want: []string{
+				"/=EOF [ with secondary error: foo: io: read/write on closed pipe]",
+				"/cause=EOF",
+				"/secondary=foo: io: read/write on closed pipe",
+				"/secondary/cause=io: read/write on closed pipe",
+			},
+		},
+		{
+			name: "skip",
+			err:  fmt.Errorf("%w, %w", fmt.Errorf("foo: %w", io.EOF), io.ErrClosedPipe),
+			fn: func(_ error, p base.Path) base.WalkAction {
+				if len(p) == 1 {
+					return base.WalkSkip
+				}
+
+				return base.WalkContinue
+			},
+			want: []string{
+				"/=foo: EOF, io: read/write on closed pipe",
+				"/[0]=foo: EOF",
+				"/[1]=io: read/write on closed pipe",
+			},
+		},
+		{
+			name: "stop",
+			err:  fmt.Errorf("%w, %w", fmt.Errorf("foo: %w", io.EOF), io.ErrClosedPipe),
+			fn: func(err error, _ base.Path) base.WalkAction {
+				if err == io.EOF {
+					return base.WalkStop
+				}
+
+				return base.WalkContinue
+			},
+			want: []string{
+				"/=foo: EOF, io: read/write on closed pipe",
+				"/[0]=foo: EOF",
+				"/[0]/cause=EOF",
+			},
+		},
+		{
+			name: "cycle",
+			err:  cerr,
+			want: []string{"/=cyclic", "/cause=cyclic"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, walkPaths(tt.err, tt.fn))
+		})
+	}
+}
+
+func TestWalkDepth(t *testing.T) {
+	var depth int
+
+	base.Walk(deepError{}, func(_ error, p base.Path) base.WalkAction {
+		depth = len(p)
+		return base.WalkContinue
+	})
+
+	assert.Equal(t, base.MaxWalkDepth, depth)
+}
+
+func TestInspect(t *testing.T) {
+	var got []string
+
+	base.Inspect(
+		secondary.WithSecondaryError(
+			multi.Combine(io.EOF, io.ErrClosedPipe),
+			io.ErrUnexpectedEOF,
+		),
+		func(err error) bool {
+			got = append(got, err.Error())
+			return err != io.EOF
+		},
+	)
+
+	assert.Equal(
+		t,
+		[]string{
+			"[EOF, io: read/write on closed pipe] [ with secondary error: unexpected EOF]",
+			"[EOF, io: read/write on closed pipe]",
+			"EOF",
+		},
+		got,
+	)
+}
+
+func TestUnwrapAll(t *testing.T) {
+	merr := multi.Combine(io.EOF, io.ErrClosedPipe)
+
+	for _, tt := range []struct {
+		err, want error
+	}{
+		{},
+		{err: io.EOF, want: io.EOF},
+		{err: fmt.Errorf("foo: %w", io.EOF), want: io.EOF},
+		{err: merr, want: merr},
+		{err: fmt.Errorf("foo: %w", errors.Join(io.EOF)), want: io.EOF},
+	} {
+		assert.Equal(t, tt.want, base.UnwrapAll(tt.err))
+	}
+
+	assert.Equal(t, io.EOF, base.UnwrapFirst(merr))
+}
+
+func TestInspectPath(t *testing.T) {
+	var (
+		got []error
+
+		merr = multi.Combine(fmt.Errorf("foo: %w", io.EOF), io.ErrClosedPipe)
+		err  = secondary.WithSecondaryError(merr, io.ErrUnexpectedEOF)
+	)
+
+	base.InspectPath(err, func(err error) bool {
+		got = append(got, err)
+		return true
+	})
+
+	assert.Equal(t, []error{err, merr, merr.(interface{ Unwrap() []error }).Unwrap()[0], io.EOF}, got)
+	assert.Equal(t, io.EOF, base.Root(err))
+	assert.Equal(t, merr, base.UnwrapAll(err))
+	assert.Nil(t, base.Root(nil))
+}
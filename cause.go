@@ -7,6 +7,9 @@ import (
 )
 
 // Cause returns the root cause of an error by recursively unwrapping it.
+// Unlike the other helpers, which follow the first branch of the multi
+// errors, see base.Inspect, a multi error holding several errors has no
+// single root cause and it is returned as is.
 func Cause(err error) error { return base.UnwrapAll(err) }
 
 // Unwrap returns the result of calling the Unwrap method on err, if err's
@@ -2,18 +2,24 @@
 
 package errors
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/upfluence/errors/base"
+)
 
 // IsOfType reports whether any error in err's tree matches the generic type T.
-// It traverses the error chain using Unwrap until it finds a match or reaches the end.
+// It traverses the tree, including the branches of the multi errors, until it
+// finds a match or reaches the end.
 func IsOfType[T error](err error) bool {
-	for {
-		if _, ok := err.(T); ok || err == nil {
-			return ok
-		}
+	var found bool
+
+	base.Inspect(err, func(err error) bool {
+		_, found = err.(T)
+		return !found
+	})
 
-		err = Unwrap(err)
-	}
+	return found
 }
 
 // AsType attempts to convert err to the generic type T by traversing the error chain.
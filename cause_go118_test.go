@@ -3,6 +3,7 @@
 package errors
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -34,6 +35,20 @@ func TestIsOfType(t *testing.T) {
 			isError:     true,
 			isMockError: true,
 		},
+		{
+			input:       Combine(New("foo"), Wrap(mockError{}, "wrapping")),
+			isError:     true,
+			isMockError: true,
+		},
+		{
+			input:       fmt.Errorf("%w: %w", New("foo"), mockError{}),
+			isError:     true,
+			isMockError: true,
+		},
+		{
+			input:   WithSecondaryError(New("foo"), mockError{}),
+			isError: true,
+		},
 	} {
 		assert.Equal(t, tt.isError, IsOfType[error](tt.input))
 		assert.Equal(t, tt.isMockError, IsOfType[mockError](tt.input))
@@ -2,9 +2,12 @@ package errors
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors/opaque"
 )
 
 type mockTimeout bool
@@ -39,10 +42,26 @@ func TestIsTimeout(t *testing.T) {
 			input:     Opaque(context.DeadlineExceeded),
 			isTimeout: false,
 		},
+		{
+			input:     OpaqueWith(context.DeadlineExceeded, opaque.ForwardTimeout),
+			isTimeout: true,
+		},
+		{
+			input:     OpaqueWith(mockTimeout(false), opaque.ForwardTimeout),
+			isTimeout: false,
+		},
 		{
 			input:     mockTimeout(true),
 			isTimeout: true,
 		},
+		{
+			input:     Combine(New("foo"), context.DeadlineExceeded),
+			isTimeout: true,
+		},
+		{
+			input:     fmt.Errorf("%w %w", New("foo"), mockTimeout(true)),
+			isTimeout: true,
+		},
 		{
 			input:     Wrap(mockTimeout(true), "wrapped"),
 			isTimeout: true,
@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"github.com/upfluence/errors/recovery"
+	"github.com/upfluence/errors/stacktrace"
+)
+
+const panicFrames = 32
+
+// checkPanic is the value Check and Must panic with, so Handle recovers them
+// and nothing else.
+type checkPanic struct {
+	err error
+}
+
+// Check panics if err is not nil, the error is recovered by Handle with the
+// frame of the call site of Check.
+func Check(err error) {
+	if err != nil {
+		panic(checkPanic{err: WithFrame(err, 1)})
+	}
+}
+
+// Must returns v, or panics like Check if err is not nil.
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(checkPanic{err: WithFrame(err, 1)})
+	}
+
+	return v
+}
+
+// Handle recovers the panics raised by Check and Must and assigns their error
+// to err, any other panic is raised again. It must be deferred directly:
+//
+//	func importAll() (err error) {
+//		defer errors.Handle(&err)
+//
+//		rows := errors.Must(fetch())
+//		errors.Check(store(rows))
+//
+//		return nil
+//	}
+func Handle(err *error) {
+	if v := recover(); v != nil {
+		*err = handle(v)
+	}
+}
+
+// HandleAll is Handle, but it also recovers the other panics and assigns
+// them to err as errors, see recovery.WrapRecoverResult.
+func HandleAll(err *error) {
+	v := recover()
+
+	if v == nil {
+		return
+	}
+
+	if cp, ok := v.(checkPanic); ok {
+		*err = cp.err
+		return
+	}
+
+	*err = stacktrace.WithStacktrace(recovery.WrapRecoverResult(v), 1, panicFrames)
+}
+
+func handle(v interface{}) error {
+	if cp, ok := v.(checkPanic); ok {
+		return cp.err
+	}
+
+	panic(v)
+}
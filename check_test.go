@@ -0,0 +1,83 @@
+package errors
+
+import (
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors/stacktrace"
+)
+
+func checked(errs ...error) (n int, err error) {
+	defer Handle(&err)
+
+	for _, err := range errs {
+		Check(err)
+		n++
+	}
+
+	return Must(n+1, nil), nil
+}
+
+func TestCheck(t *testing.T) {
+	n, err := checked(nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	n, err = checked(nil, io.EOF, nil)
+
+	assert.Equal(t, io.EOF, Cause(err))
+	assert.Equal(t, 1, n)
+
+	fn, _, _ := stacktrace.GetFrames(err)[0].Location()
+
+	assert.Equal(t, "github.com/upfluence/errors.checked", fn)
+}
+
+func TestMust(t *testing.T) {
+	var err error
+
+	func() {
+		defer Handle(&err)
+
+		Must(0, io.EOF)
+	}()
+
+	assert.Equal(t, io.EOF, Cause(err))
+}
+
+func TestHandleForeignPanic(t *testing.T) {
+	assert.PanicsWithValue(t, "foo", func() {
+		var err error
+
+		defer Handle(&err)
+
+		panic("foo")
+	})
+
+	var err error
+
+	func() {
+		defer HandleAll(&err)
+
+		var s []int
+
+		_ = s[1]
+	}()
+
+	var rerr runtime.Error
+
+	assert.True(t, As(err, &rerr))
+	assert.NotEmpty(t, stacktrace.GetFrames(err))
+
+	func() {
+		defer HandleAll(&err)
+
+		Check(io.ErrUnexpectedEOF)
+	}()
+
+	assert.Equal(t, io.ErrUnexpectedEOF, Cause(err))
+}
@@ -0,0 +1,8 @@
+package errors
+
+import "github.com/upfluence/errors/code"
+
+// WithCode attaches a canonical code to the error and adds a stack frame.
+func WithCode(err error, c code.Code) error {
+	return WithFrame(code.WithCode(err, c), 1)
+}
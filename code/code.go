@@ -0,0 +1,140 @@
+// Package code provides canonical error classes.
+//
+// A code classifies an error in a small set of well-known classes, such as
+// "not found" or "invalid argument", shared across services. Each code maps
+// to an HTTP status and to a status string usable for metrics. Codes are
+// attached with WithCode and extracted with GetCode, which also infers the
+// code of well-known standard library errors.
+package code
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"os"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/value"
+)
+
+// Code is a canonical error class.
+type Code int
+
+const (
+	OK Code = iota
+	Canceled
+	Unknown
+	InvalidArgument
+	DeadlineExceeded
+	NotFound
+	AlreadyExists
+	PermissionDenied
+	ResourceExhausted
+	FailedPrecondition
+	Aborted
+	OutOfRange
+	Unimplemented
+	Internal
+	Unavailable
+	DataLoss
+	Unauthenticated
+)
+
+type codeDefinition struct {
+	status     string
+	httpStatus int
+}
+
+var definitions = map[Code]codeDefinition{
+	OK:                 {status: "ok", httpStatus: http.StatusOK},
+	Canceled:           {status: "canceled", httpStatus: 499},
+	Unknown:            {status: "unknown", httpStatus: http.StatusInternalServerError},
+	InvalidArgument:    {status: "invalid_argument", httpStatus: http.StatusBadRequest},
+	DeadlineExceeded:   {status: "deadline_exceeded", httpStatus: http.StatusGatewayTimeout},
+	NotFound:           {status: "not_found", httpStatus: http.StatusNotFound},
+	AlreadyExists:      {status: "already_exists", httpStatus: http.StatusConflict},
+	PermissionDenied:   {status: "permission_denied", httpStatus: http.StatusForbidden},
+	ResourceExhausted:  {status: "resource_exhausted", httpStatus: http.StatusTooManyRequests},
+	FailedPrecondition: {status: "failed_precondition", httpStatus: http.StatusBadRequest},
+	Aborted:            {status: "aborted", httpStatus: http.StatusConflict},
+	OutOfRange:         {status: "out_of_range", httpStatus: http.StatusBadRequest},
+	Unimplemented:      {status: "unimplemented", httpStatus: http.StatusNotImplemented},
+	Internal:           {status: "internal", httpStatus: http.StatusInternalServerError},
+	Unavailable:        {status: "unavailable", httpStatus: http.StatusServiceUnavailable},
+	DataLoss:           {status: "data_loss", httpStatus: http.StatusInternalServerError},
+	Unauthenticated:    {status: "unauthenticated", httpStatus: http.StatusUnauthorized},
+}
+
+// String returns the status string of the code, as used by the stats
+// package.
+func (c Code) String() string {
+	if d, ok := definitions[c]; ok {
+		return d.status
+	}
+
+	return definitions[Unknown].status
+}
+
+// HTTPStatus returns the HTTP status matching the code.
+func (c Code) HTTPStatus() int {
+	if d, ok := definitions[c]; ok {
+		return d.httpStatus
+	}
+
+	return definitions[Unknown].httpStatus
+}
+
+var wellKnownErrors = []struct {
+	err  error
+	code Code
+}{
+	{err: context.DeadlineExceeded, code: DeadlineExceeded},
+	{err: context.Canceled, code: Canceled},
+	{err: os.ErrNotExist, code: NotFound},
+	{err: os.ErrExist, code: AlreadyExists},
+	{err: os.ErrPermission, code: PermissionDenied},
+	{err: sql.ErrNoRows, code: NotFound},
+}
+
+type codeKey struct{}
+
+func (codeKey) Resolve(err error) (interface{}, bool) {
+	c := GetCode(err)
+	return c, c != Unknown
+}
+
+// GetCode extracts the code from an error by traversing the error tree, see
+// base.Inspect for the precedence rule. Errors hiding their chain, like
+// opaque errors, expose the code of their cause. If no code is attached, the
+// code is inferred from well-known standard library errors.
+// Returns OK if err is nil, and Unknown if no code is found.
+func GetCode(err error) Code {
+	if err == nil {
+		return OK
+	}
+
+	var code = Unknown
+
+	base.Inspect(err, func(err error) bool {
+		if c, ok := err.(interface{ Code() Code }); ok {
+			code = c.Code()
+		} else if v, ok := value.Own(err, codeKey{}); ok {
+			code = v.(Code)
+		}
+
+		return code == Unknown
+	})
+
+	if code != Unknown {
+		return code
+	}
+
+	for _, wke := range wellKnownErrors {
+		if errors.Is(err, wke.err) {
+			return wke.code
+		}
+	}
+
+	return Unknown
+}
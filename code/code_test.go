@@ -0,0 +1,86 @@
+package code_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/code"
+	"github.com/upfluence/errors/errtest"
+	"github.com/upfluence/errors/stats"
+)
+
+func TestWithCode(t *testing.T) {
+	errtest.TestErrorWrapper(
+		t,
+		func(err error) error { return errors.WithCode(err, code.NotFound) },
+		errtest.ErrorWrapperOptions{N: 2},
+	)
+}
+
+func TestGetCode(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want code.Code
+	}{
+		{name: "nil", want: code.OK},
+		{name: "no code", err: errors.New("foo"), want: code.Unknown},
+		{
+			name: "with code",
+			err:  errors.WithCode(errors.New("foo"), code.InvalidArgument),
+			want: code.InvalidArgument,
+		},
+		{
+			name: "wrapped",
+			err:  errors.Wrap(errors.WithCode(io.EOF, code.Unavailable), "bar"),
+			want: code.Unavailable,
+		},
+		{
+			name: "outermost wins",
+			err:  errors.WithCode(errors.WithCode(io.EOF, code.Unavailable), code.Internal),
+			want: code.Internal,
+		},
+		{
+			name: "opaque",
+			err:  errors.Opaque(errors.WithCode(io.EOF, code.Unauthenticated)),
+			want: code.Unauthenticated,
+		},
+		{
+			name: "explicit code wins over inference",
+			err:  errors.WithCode(context.Canceled, code.Aborted),
+			want: code.Aborted,
+		},
+		{name: "deadline", err: errors.Wrap(context.DeadlineExceeded, "foo"), want: code.DeadlineExceeded},
+		{name: "canceled", err: context.Canceled, want: code.Canceled},
+		{name: "not exist", err: &os.PathError{Op: "open", Err: os.ErrNotExist}, want: code.NotFound},
+		{name: "permission", err: fmt.Errorf("foo: %w", os.ErrPermission), want: code.PermissionDenied},
+		{name: "no rows", err: errors.WithStack(sql.ErrNoRows), want: code.NotFound},
+		{name: "opaque inferred", err: errors.Opaque(sql.ErrNoRows), want: code.NotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, code.GetCode(tt.err))
+		})
+	}
+}
+
+func TestCodeMapping(t *testing.T) {
+	assert.Equal(t, "not_found", code.NotFound.String())
+	assert.Equal(t, http.StatusNotFound, code.NotFound.HTTPStatus())
+	assert.Equal(t, http.StatusUnauthorized, code.Unauthenticated.HTTPStatus())
+	assert.Equal(t, "unknown", code.Code(-1).String())
+	assert.Equal(t, http.StatusInternalServerError, code.Code(-1).HTTPStatus())
+
+	assert.Equal(
+		t,
+		"resource_exhausted",
+		stats.GetStatus(errors.WithCode(errors.New("foo"), code.ResourceExhausted)),
+	)
+}
@@ -0,0 +1,40 @@
+package code
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/internal/logvalue"
+	"github.com/upfluence/errors/stacktrace"
+)
+
+type withCode struct {
+	cause error
+	code  Code
+}
+
+func (wc *withCode) Error() string  { return wc.cause.Error() }
+func (wc *withCode) Unwrap() error  { return wc.cause }
+func (wc *withCode) Cause() error   { return wc.cause }
+func (wc *withCode) Code() Code     { return wc.code }
+func (wc *withCode) Status() string { return wc.code.String() }
+
+func (wc *withCode) Format(s fmt.State, verb rune) { base.FormatError(wc, s, verb) }
+func (wc *withCode) LogValue() slog.Value          { return logvalue.Of(wc, stacktrace.Origin(wc)) }
+
+func (wc *withCode) FormatError(p base.Printer) error {
+	p.Printf("code: %s", wc.code)
+	return wc.cause
+}
+
+// WithCode attaches a code to the error. The code is also exposed as the
+// status of the error.
+// Returns nil if err is nil.
+func WithCode(err error, c Code) error {
+	if err == nil {
+		return nil
+	}
+
+	return &withCode{cause: err, code: c}
+}
@@ -0,0 +1,23 @@
+package errors
+
+import (
+	"context"
+
+	"github.com/upfluence/errors/message"
+	"github.com/upfluence/errors/tags"
+)
+
+// WithContext attaches the tags carried by ctx to the error and adds a stack
+// frame. See tags.NewContext.
+func WithContext(ctx context.Context, err error) error {
+	return WithFrame(tags.WithTags(err, tags.FromContext(ctx)), 1)
+}
+
+// WrapContext wraps an error with an additional message and stack frame, and
+// attaches the tags carried by ctx. See tags.NewContext.
+func WrapContext(ctx context.Context, err error, msg string) error {
+	return WithFrame(
+		message.WithMessage(tags.WithTags(err, tags.FromContext(ctx)), msg),
+		1,
+	)
+}
@@ -0,0 +1,75 @@
+package errors
+
+import (
+	"io"
+
+	"github.com/upfluence/errors/message"
+	"github.com/upfluence/errors/secondary"
+)
+
+// Defer calls fn and merges its error into *err: it becomes the result if
+// *err is nil, it is attached as a secondary error otherwise. It is meant to
+// be deferred by functions with a named error result:
+//
+//	defer errors.Defer(&err, flush)
+func Defer(err *error, fn func() error) {
+	deferred(err, fn(), "")
+}
+
+// Close closes c and merges its error into *err, see Defer.
+//
+//	defer errors.Close(&err, f)
+func Close(err *error, c io.Closer) {
+	deferred(err, c.Close(), "")
+}
+
+// CloseWithMessage is Close, the close error being wrapped with msg.
+func CloseWithMessage(err *error, c io.Closer, msg string) {
+	deferred(err, c.Close(), msg)
+}
+
+// Tx is implemented by transactions, like *sql.Tx.
+type Tx interface {
+	Commit() error
+	Rollback() error
+}
+
+// CommitOrRollback commits tx if *err is nil, or rolls it back otherwise,
+// and merges the error of the commit or of the rollback into *err, see
+// Defer. A panic in flight rolls tx back and is raised again. It must be
+// deferred directly:
+//
+//	defer errors.CommitOrRollback(&err, tx)
+func CommitOrRollback(err *error, tx Tx) {
+	if v := recover(); v != nil {
+		tx.Rollback()
+		panic(v)
+	}
+
+	if *err != nil {
+		deferred(err, tx.Rollback(), "rollback")
+		return
+	}
+
+	deferred(err, tx.Commit(), "commit")
+}
+
+func deferred(err *error, derr error, msg string) {
+	if derr == nil {
+		return
+	}
+
+	if msg != "" {
+		derr = message.WithMessage(derr, msg)
+	}
+
+	// the frame of the function deferring the call
+	derr = WithFrame(derr, 2)
+
+	if *err == nil {
+		*err = derr
+		return
+	}
+
+	*err = secondary.WithSecondaryError(*err, derr)
+}
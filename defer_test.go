@@ -0,0 +1,147 @@
+package errors
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors/stacktrace"
+)
+
+type mockCloser struct {
+	err    error
+	closed bool
+}
+
+func (mc *mockCloser) Close() error {
+	mc.closed = true
+	return mc.err
+}
+
+func closing(c io.Closer, err error) (rerr error) {
+	defer Close(&rerr, c)
+
+	return err
+}
+
+func TestClose(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		closeErr error
+		err      error
+
+		want      string
+		wantCause error
+	}{
+		{name: "success"},
+		{
+			name:      "close error",
+			closeErr:  io.ErrClosedPipe,
+			want:      "io: read/write on closed pipe",
+			wantCause: io.ErrClosedPipe,
+		},
+		{name: "error", err: io.EOF, want: "EOF", wantCause: io.EOF},
+		{
+			name:      "both",
+			closeErr:  io.ErrClosedPipe,
+			err:       io.EOF,
+			want:      "EOF [ with secondary error: io: read/write on closed pipe]",
+			wantCause: io.EOF,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := mockCloser{err: tt.closeErr}
+
+			err := closing(&c, tt.err)
+
+			assert.True(t, c.closed)
+
+			if tt.want == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Equal(t, tt.want, err.Error())
+			assert.Equal(t, tt.wantCause, Cause(err))
+		})
+	}
+
+	err := closing(&mockCloser{err: io.ErrClosedPipe}, nil)
+
+	fn, _, _ := stacktrace.GetFrames(err)[0].Location()
+
+	assert.Equal(t, "github.com/upfluence/errors.closing", fn)
+}
+
+func TestCloseWithMessage(t *testing.T) {
+	err := func() (err error) {
+		defer CloseWithMessage(&err, &mockCloser{err: io.ErrClosedPipe}, "close output")
+		return nil
+	}()
+
+	assert.Equal(t, "close output: io: read/write on closed pipe", err.Error())
+}
+
+func TestDefer(t *testing.T) {
+	err := func() (err error) {
+		defer Defer(&err, func() error { return io.ErrClosedPipe })
+		return io.EOF
+	}()
+
+	assert.Equal(t, "EOF [ with secondary error: io: read/write on closed pipe]", err.Error())
+	assert.Equal(t, io.EOF, Cause(err))
+}
+
+type mockTx struct {
+	commitErr, rollbackErr error
+
+	committed, rolledBack bool
+}
+
+func (tx *mockTx) Commit() error {
+	tx.committed = true
+	return tx.commitErr
+}
+
+func (tx *mockTx) Rollback() error {
+	tx.rolledBack = true
+	return tx.rollbackErr
+}
+
+func TestCommitOrRollback(t *testing.T) {
+	run := func(tx *mockTx, err error) (rerr error) {
+		defer CommitOrRollback(&rerr, tx)
+		return err
+	}
+
+	var tx mockTx
+
+	assert.NoError(t, run(&tx, nil))
+	assert.True(t, tx.committed)
+	assert.False(t, tx.rolledBack)
+
+	tx = mockTx{commitErr: io.ErrClosedPipe}
+
+	assert.Equal(t, "commit: io: read/write on closed pipe", run(&tx, nil).Error())
+
+	tx = mockTx{rollbackErr: io.ErrClosedPipe}
+
+	err := run(&tx, io.EOF)
+
+	assert.False(t, tx.committed)
+	assert.True(t, tx.rolledBack)
+	assert.Equal(t, "EOF [ with secondary error: rollback: io: read/write on closed pipe]", err.Error())
+
+	tx = mockTx{}
+
+	assert.PanicsWithValue(t, "foo", func() {
+		var err error
+
+		defer CommitOrRollback(&err, &tx)
+
+		panic("foo")
+	})
+	assert.True(t, tx.rolledBack)
+	assert.False(t, tx.committed)
+}
@@ -31,20 +31,20 @@ func PackageDomainAtDepth(depth int) Domain {
 	return Domain(stacktrace.PackageName(fn))
 }
 
-// GetDomain extracts the domain from an error by traversing the error chain.
+// GetDomain extracts the domain from an error by traversing the error tree,
+// the outermost domain is used, see base.Inspect for the precedence rule.
 // Returns NoDomain if no domain is found.
 func GetDomain(err error) Domain {
-	for {
+	var d = NoDomain
+
+	base.Inspect(err, func(err error) bool {
 		if wd, ok := err.(interface{ Domain() Domain }); ok {
-			return wd.Domain()
+			d = wd.Domain()
+			return false
 		}
 
-		err = base.UnwrapOnce(err)
-
-		if err == nil {
-			break
-		}
-	}
+		return true
+	})
 
-	return NoDomain
+	return d
 }
@@ -36,4 +36,12 @@ func TestDomain(t *testing.T) {
 		domain.NoDomain,
 		domain.GetDomain(fmt.Errorf("error")),
 	)
+
+	assert.Equal(
+		t,
+		domain.Domain("bar"),
+		domain.GetDomain(
+			fmt.Errorf("%w, %w", fmt.Errorf("error"), domain.WithDomain(fmt.Errorf("error"), "bar")),
+		),
+	)
 }
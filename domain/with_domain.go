@@ -1,6 +1,14 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/internal/logvalue"
+	"github.com/upfluence/errors/stacktrace"
+)
 
 type withDomain struct {
 	cause error
@@ -13,6 +21,14 @@ func (ws *withDomain) Unwrap() error  { return ws.cause }
 func (ws *withDomain) Cause() error   { return ws.cause }
 func (ws *withDomain) Domain() Domain { return ws.domain }
 
+func (ws *withDomain) Format(s fmt.State, verb rune) { base.FormatError(ws, s, verb) }
+func (ws *withDomain) LogValue() slog.Value          { return logvalue.Of(ws, stacktrace.Origin(ws)) }
+
+func (ws *withDomain) FormatError(p base.Printer) error {
+	p.Printf("domain: %s", ws.domain)
+	return ws.cause
+}
+
 func (ws *withDomain) Tags() map[string]interface{} {
 	return map[string]interface{}{"domain": string(ws.domain)}
 }
@@ -0,0 +1,142 @@
+// Package errjson provides a stable JSON representation of error chains.
+//
+// An encoded error is an object holding the full error message and the chain
+// of layers that built it, from the outermost wrapper to the root cause:
+//
+//	{
+//	  "message": "fetch user: not found",
+//	  "chain": [
+//	    {
+//	      "type": "*message.withMessage",
+//	      "message": "fetch user",
+//	      "frames": [{"function": "main.fetch", "file": "/src/main.go", "line": 12}]
+//	    },
+//	    {
+//	      "type": "*opaque.opaqueError",
+//	      "message": "not found",
+//	      "domain": "main",
+//	      "tags": {"user.id": 42}
+//	    }
+//	  ]
+//	}
+//
+// Every node holds the metadata attached by its own layer only:
+//
+//   - type: the Go type of the layer, always present
+//   - code: the code the layer is registered under, see the registry package
+//   - message: the message added by the layer, the full message for root
+//     causes and foreign wrappers
+//   - frames: the resolved stack frames captured by the layer
+//   - tags: the tags attached by the layer
+//   - domain: the domain attached by the layer
+//   - status: the status attached by the layer
+//   - hints: the hints attached by the layer, see the hint package
+//   - details: the details attached by the layer
+//   - errors: one chain per branch of a multi error
+//   - secondary: the chain of a secondary error
+//
+// Opaque errors are encoded as root causes carrying the metadata they expose.
+// Tag values that can not be encoded as JSON are replaced by their fmt %v
+// representation, the sensitive ones by tags.Redacted.
+package errjson
+
+import (
+	"encoding/json"
+
+	"github.com/upfluence/errors/internal/tree"
+	"github.com/upfluence/errors/tags"
+)
+
+// Error is the JSON representation of an error.
+type Error struct {
+	Message string `json:"message"`
+	Chain   []Node `json:"chain"`
+}
+
+// Node is the JSON representation of one layer of an error chain.
+type Node struct {
+	Type    string `json:"type"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+
+	Frames []Frame                `json:"frames,omitempty"`
+	Tags   map[string]interface{} `json:"tags,omitempty"`
+	Domain string                 `json:"domain,omitempty"`
+	Status string                 `json:"status,omitempty"`
+
+	Hints   []string `json:"hints,omitempty"`
+	Details []string `json:"details,omitempty"`
+
+	Errors    [][]Node `json:"errors,omitempty"`
+	Secondary []Node   `json:"secondary,omitempty"`
+}
+
+// Frame is the JSON representation of a resolved stack frame.
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// Encode builds the JSON representation of err.
+// Returns nil if err is nil.
+func Encode(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	return &Error{Message: err.Error(), Chain: encodeChain(err)}
+}
+
+// Marshal returns the JSON encoding of err.
+// A nil error is encoded as null.
+func Marshal(err error) ([]byte, error) {
+	return json.Marshal(Encode(err))
+}
+
+func encodeChain(err error) []Node { return fromTree(tree.Encode(err)) }
+
+func fromTree(tns []tree.Node) []Node {
+	if tns == nil {
+		return nil
+	}
+
+	ns := make([]Node, len(tns))
+
+	for i, tn := range tns {
+		n := Node{
+			Type:    tn.Type,
+			Code:    tn.Code,
+			Message: tn.Text,
+			Tags:    tn.Tags,
+			Domain:  tn.Domain,
+			Status:  tn.Status,
+			Hints:   tn.Hints,
+			Details: tn.Details,
+
+			Secondary: fromTree(tn.Secondary),
+		}
+
+		if tn.Message != nil {
+			n.Message = *tn.Message
+		}
+
+		// the documents end up in the log pipelines, they never carry the
+		// sensitive values
+		for _, k := range tn.Sensitive {
+			n.Tags[k] = tags.Redacted
+		}
+
+		for _, f := range tn.Frames {
+			n.Frames = append(n.Frames, Frame{Function: f.Function, File: f.File, Line: f.Line})
+		}
+
+		for _, b := range tn.Errors {
+			n.Errors = append(n.Errors, fromTree(b))
+		}
+
+		ns[i] = n
+	}
+
+	return ns
+}
@@ -0,0 +1,105 @@
+package errjson_test
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/errjson"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestEncode(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+	}{
+		{name: "nil"},
+		{name: "stdlib", err: io.EOF},
+		{name: "sentinel", err: errors.New("foo")},
+		{name: "wrapped", err: errors.Wrapf(io.EOF, "read %q", "body")},
+		{
+			name: "metadata",
+			err: errors.WithStatus(
+				errors.WithTags(
+					errors.WithDomain(io.EOF, "storage"),
+					map[string]interface{}{"user.id": 42, "ratio": complex(1, 2)},
+				),
+				"read_failed",
+			),
+		},
+		{
+			name: "multi",
+			err:  errors.Combine(io.EOF, fmt.Errorf("wrapped: %w", io.ErrUnexpectedEOF)),
+		},
+		{
+			name: "hints",
+			err:  errors.WithHint(errors.Opaque(errors.WithDetail(io.EOF, "read body")), "retry later"),
+		},
+		{
+			name: "secondary",
+			err:  errors.WithSecondaryError(io.EOF, errors.Wrap(io.ErrClosedPipe, "close")),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			e := errjson.Encode(tt.err)
+
+			if e != nil {
+				trimFiles(e.Chain)
+			}
+
+			buf, err := json.MarshalIndent(e, "", "  ")
+			assert.NoError(t, err)
+
+			golden := filepath.Join("testdata", tt.name+".golden")
+
+			if *update {
+				assert.NoError(t, os.WriteFile(golden, buf, 0644))
+			}
+
+			want, err := os.ReadFile(golden)
+
+			assert.NoError(t, err)
+			assert.Equal(t, string(want), string(buf))
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	buf, err := errjson.Marshal(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(buf))
+
+	buf, err = errjson.Marshal(errors.Wrap(io.EOF, "foo"))
+
+	assert.NoError(t, err)
+
+	var e errjson.Error
+
+	assert.NoError(t, json.Unmarshal(buf, &e))
+	assert.Equal(t, "foo: EOF", e.Message)
+	assert.Len(t, e.Chain, 3)
+}
+
+func trimFiles(ns []errjson.Node) {
+	for i := range ns {
+		for j := range ns[i].Frames {
+			ns[i].Frames[j].File = filepath.Base(ns[i].Frames[j].File)
+		}
+
+		for _, c := range ns[i].Errors {
+			trimFiles(c)
+		}
+
+		trimFiles(ns[i].Secondary)
+	}
+}
@@ -0,0 +1,261 @@
+// Package errslog provides a log/slog integration for errors.
+//
+// The Handler wraps another slog.Handler, expands every error-valued
+// attribute into a group holding its message, domain, status, tags and
+// origin frame, and forwards the errors of records logged at the report
+// level or above to a reporter.Reporter.
+package errslog
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"slices"
+
+	"github.com/upfluence/errors/internal/logvalue"
+	"github.com/upfluence/errors/reporter"
+	"github.com/upfluence/errors/stacktrace"
+	"github.com/upfluence/errors/tags"
+	"github.com/upfluence/log/record"
+)
+
+// Option configures a Handler.
+type Option func(*options)
+
+type options struct {
+	reporter    reporter.Reporter
+	reportLevel slog.Level
+}
+
+var defaultOptions = options{
+	reporter:    reporter.NopReporter,
+	reportLevel: slog.LevelError,
+}
+
+// WithReporter sets the reporter receiving the errors of reported records.
+func WithReporter(r reporter.Reporter) Option {
+	return func(o *options) { o.reporter = r }
+}
+
+// WithReportLevel sets the minimum level of the records whose errors are
+// reported. It defaults to slog.LevelError.
+func WithReportLevel(l slog.Level) Option {
+	return func(o *options) { o.reportLevel = l }
+}
+
+// Handler is a slog.Handler middleware expanding and reporting errors.
+type Handler struct {
+	next slog.Handler
+	opts options
+
+	prefix string
+	errs   []error
+	tags   map[string]interface{}
+}
+
+// NewHandler creates a Handler wrapping next.
+func NewHandler(next slog.Handler, opts ...Option) *Handler {
+	var o = defaultOptions
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Handler{next: next, opts: o}
+}
+
+// Value returns the slog representation of err. Errors built by this module
+// already implement slog.LogValuer, Value gives the same representation to
+// any other error.
+func Value(err error) slog.Value {
+	if lv, ok := err.(slog.LogValuer); ok {
+		return lv.LogValue()
+	}
+
+	return logvalue.Of(err, stacktrace.Origin(err))
+}
+
+// Enabled reports whether the wrapped handler handles records at level l.
+func (h *Handler) Enabled(ctx context.Context, l slog.Level) bool {
+	return h.next.Enabled(ctx, l)
+}
+
+// Handle expands the errors of r, reports them if r is logged at the report
+// level or above, and forwards the record to the wrapped handler. The
+// reports carry the attributes of the record and the tags of ctx, and the
+// depth of the code logging r, so the reporters point at it rather than at
+// the handlers.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	var (
+		c = collector{tags: cloneTags(h.tags)}
+
+		nr = slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	)
+
+	r.Attrs(func(a slog.Attr) bool {
+		nr.AddAttrs(c.expand(h.prefix, a))
+		return true
+	})
+
+	if r.Level >= h.opts.reportLevel {
+		var (
+			lvl   = recordLevel(r.Level)
+			depth = callerDepth(r.PC)
+		)
+
+		for k, v := range tags.FromContext(ctx) {
+			if _, ok := c.tags[k]; !ok {
+				c.setTag(k, v)
+			}
+		}
+
+		// h.errs may have spare capacity shared by concurrent calls
+		for _, err := range append(slices.Clip(h.errs), c.errs...) {
+			h.opts.reporter.Report(
+				err,
+				reporter.ReportOptions{Tags: c.tags, Depth: depth, ReportedLevel: &lvl},
+			)
+		}
+	}
+
+	return h.next.Handle(ctx, nr)
+}
+
+// WithAttrs returns a Handler whose attributes consist of both the receiver's
+// attributes and attrs.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var (
+		c   = collector{tags: cloneTags(h.tags)}
+		nas = make([]slog.Attr, len(attrs))
+	)
+
+	for i, a := range attrs {
+		nas[i] = c.expand(h.prefix, a)
+	}
+
+	return &Handler{
+		next:   h.next.WithAttrs(nas),
+		opts:   h.opts,
+		prefix: h.prefix,
+		errs:   append(append([]error(nil), h.errs...), c.errs...),
+		tags:   c.tags,
+	}
+}
+
+// WithGroup returns a Handler starting a group.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{
+		next:   h.next.WithGroup(name),
+		opts:   h.opts,
+		prefix: h.prefix + name + ".",
+		errs:   h.errs,
+		tags:   h.tags,
+	}
+}
+
+type collector struct {
+	errs []error
+	tags map[string]interface{}
+}
+
+func (c *collector) expand(prefix string, a slog.Attr) slog.Attr {
+	if err, ok := a.Value.Any().(error); ok {
+		c.errs = append(c.errs, err)
+
+		return slog.Attr{Key: a.Key, Value: Value(err)}
+	}
+
+	if sv, ok := a.Value.Any().(tags.SensitiveValue); ok {
+		// resolving a sensitive value redacts it, the reporter is given the
+		// unresolved one and decides whether to reveal it
+		c.setTag(prefix+a.Key, sv)
+
+		return slog.String(a.Key, tags.Redacted)
+	}
+
+	if a.Value.Kind() == slog.KindLogValuer {
+		a.Value = a.Value.Resolve()
+	}
+
+	if a.Value.Kind() != slog.KindGroup {
+		c.setTag(prefix+a.Key, a.Value.Any())
+
+		// the raw value is kept for the reporter, the log always masks it
+		if tags.IsSensitive(prefix+a.Key, a.Value.Any()) {
+			return slog.String(a.Key, tags.Redacted)
+		}
+
+		return a
+	}
+
+	var (
+		gas = a.Value.Group()
+		nas = make([]slog.Attr, len(gas))
+	)
+
+	for i, ga := range gas {
+		nas[i] = c.expand(prefix+a.Key+".", ga)
+	}
+
+	return slog.Attr{Key: a.Key, Value: slog.GroupValue(nas...)}
+}
+
+func (c *collector) setTag(k string, v interface{}) {
+	if c.tags == nil {
+		c.tags = make(map[string]interface{})
+	}
+
+	c.tags[k] = v
+}
+
+// callerDepth returns the depth of the frame of pc from the caller of Handle:
+// the logger and the handlers wrapping this one sit in between. Returns 0,
+// the depth of Handle itself, if the frame is not found, as for the records
+// built by hand or handled asynchronously.
+func callerDepth(pc uintptr) int {
+	if pc == 0 {
+		return 0
+	}
+
+	var pcs [32]uintptr
+
+	// skip runtime.Callers, callerDepth and Handle
+	n := runtime.Callers(3, pcs[:])
+
+	for i, p := range pcs[:n] {
+		if p == pc {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+func cloneTags(ts map[string]interface{}) map[string]interface{} {
+	if ts == nil {
+		return nil
+	}
+
+	res := make(map[string]interface{}, len(ts))
+
+	for k, v := range ts {
+		res[k] = v
+	}
+
+	return res
+}
+
+func recordLevel(l slog.Level) record.Level {
+	switch {
+	case l >= slog.LevelError+4:
+		return record.Fatal
+	case l >= slog.LevelError:
+		return record.Error
+	case l >= slog.LevelWarn:
+		return record.Warning
+	case l >= slog.LevelInfo:
+		return record.Info
+	default:
+		return record.Debug
+	}
+}
@@ -0,0 +1,250 @@
+package errslog_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/errslog"
+	"github.com/upfluence/errors/reporter"
+	"github.com/upfluence/errors/stacktrace"
+	"github.com/upfluence/errors/tags"
+	"github.com/upfluence/log/record"
+)
+
+type report struct {
+	err  error
+	opts reporter.ReportOptions
+}
+
+type mockReporter struct {
+	reports []report
+}
+
+func (*mockReporter) Close() error { return nil }
+
+func (mr *mockReporter) Report(err error, opts reporter.ReportOptions) {
+	mr.reports = append(mr.reports, report{err: err, opts: opts})
+}
+
+func TestLogValue(t *testing.T) {
+	err := errors.WithStatus(
+		errors.WithTags(errors.New("foo"), map[string]interface{}{"user.id": 42}),
+		"failed",
+	)
+
+	lv, ok := err.(slog.LogValuer)
+
+	assert.True(t, ok)
+
+	attrs := lv.LogValue().Group()
+
+	assert.Equal(t, "message", attrs[0].Key)
+	assert.Equal(t, "foo", attrs[0].Value.String())
+	assert.Equal(t, "domain", attrs[1].Key)
+	assert.Equal(t, "github.com/upfluence/errors/errslog_test", attrs[1].Value.String())
+	assert.Equal(t, "status", attrs[2].Key)
+	assert.Equal(t, "failed", attrs[2].Value.String())
+	assert.Equal(t, "tags", attrs[3].Key)
+	assert.Equal(t, "user.id", attrs[3].Value.Group()[0].Key)
+	assert.Equal(t, "origin", attrs[4].Key)
+	assert.Equal(
+		t,
+		"github.com/upfluence/errors/errslog_test.TestLogValue",
+		attrs[4].Value.Group()[0].Value.String(),
+	)
+}
+
+func TestHandler(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		mr  mockReporter
+
+		l = slog.New(
+			errslog.NewHandler(
+				slog.NewJSONHandler(&buf, nil),
+				errslog.WithReporter(&mr),
+			),
+		)
+	)
+
+	l.With("request", "abc").WithGroup("job").Info("retrying", "err", io.EOF, "attempt", 1)
+
+	var out map[string]interface{}
+
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(
+		t,
+		map[string]interface{}{
+			"attempt": 1.0,
+			"err":     map[string]interface{}{"message": "EOF"},
+		},
+		out["job"],
+	)
+	assert.Empty(t, mr.reports)
+
+	buf.Reset()
+
+	err := errors.Wrap(io.EOF, "read")
+	l.With("request", "abc").WithGroup("job").Error("failed", "err", err, "attempt", 2)
+
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(
+		t,
+		"read: EOF",
+		out["job"].(map[string]interface{})["err"].(map[string]interface{})["message"],
+	)
+
+	assert.Len(t, mr.reports, 1)
+	assert.Equal(t, err, mr.reports[0].err)
+	assert.Equal(
+		t,
+		map[string]interface{}{"request": "abc", "job.attempt": int64(2)},
+		mr.reports[0].opts.Tags,
+	)
+	assert.Equal(t, record.Error, *mr.reports[0].opts.ReportedLevel)
+}
+
+type callerReporter struct {
+	fn   string
+	line int
+}
+
+func (*callerReporter) Close() error { return nil }
+
+func (cr *callerReporter) Report(_ error, opts reporter.ReportOptions) {
+	// a depth of 0 is the caller of Report
+	cr.fn, _, cr.line = stacktrace.Caller(opts.Depth + 1).Location()
+}
+
+type middleware struct{ slog.Handler }
+
+func (m middleware) Handle(ctx context.Context, r slog.Record) error {
+	return m.Handler.Handle(ctx, r)
+}
+
+func TestHandlerDepth(t *testing.T) {
+	var (
+		cr callerReporter
+
+		l = slog.New(
+			errslog.NewHandler(slog.NewTextHandler(io.Discard, nil), errslog.WithReporter(&cr)),
+		)
+	)
+
+	_, _, line, _ := runtime.Caller(0)
+	l.With("request", "abc").Error("failed", "err", io.EOF)
+
+	assert.Equal(t, "github.com/upfluence/errors/errslog_test.TestHandlerDepth", cr.fn)
+	assert.Equal(t, line+1, cr.line)
+
+	_, _, line, _ = runtime.Caller(0)
+	slog.New(middleware{l.Handler()}).Error("failed", "err", io.EOF)
+
+	assert.Equal(t, "github.com/upfluence/errors/errslog_test.TestHandlerDepth", cr.fn)
+	assert.Equal(t, line+1, cr.line)
+}
+
+func TestHandlerWithAttrsError(t *testing.T) {
+	var (
+		mr mockReporter
+
+		h = errslog.NewHandler(
+			slog.NewTextHandler(io.Discard, nil),
+			errslog.WithReporter(&mr),
+			errslog.WithReportLevel(slog.LevelWarn),
+		)
+	)
+
+	slog.New(h).With("err", io.EOF).Warn("failed")
+
+	assert.Len(t, mr.reports, 1)
+	assert.Equal(t, io.EOF, mr.reports[0].err)
+	assert.Equal(t, record.Warning, *mr.reports[0].opts.ReportedLevel)
+	assert.True(t, h.Enabled(context.Background(), slog.LevelInfo))
+}
+
+func TestHandlerSensitive(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		mr  mockReporter
+
+		l = slog.New(
+			errslog.NewHandler(
+				slog.NewJSONHandler(&buf, nil),
+				errslog.WithReporter(&mr),
+			),
+		)
+	)
+
+	l.Error(
+		"failed",
+		"err", errors.WithTag(errors.New("foo"), reporter.UserEmailTag, "john@example.com"),
+		reporter.UserEmailKey, "jane@example.com",
+		"token", errors.Sensitive("secret"),
+	)
+
+	assert.NotContains(t, buf.String(), "@example.com")
+	assert.NotContains(t, buf.String(), "secret")
+	assert.Len(t, mr.reports, 1)
+	assert.Equal(t, "jane@example.com", mr.reports[0].opts.Tags[reporter.UserEmailKey])
+	assert.Equal(t, "secret", tags.Reveal(mr.reports[0].opts.Tags["token"]))
+	assert.True(t, tags.IsSensitive("token", mr.reports[0].opts.Tags["token"]))
+}
+
+type syncReporter struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func (*syncReporter) Close() error { return nil }
+
+func (sr *syncReporter) Report(err error, _ reporter.ReportOptions) {
+	sr.mu.Lock()
+	sr.errs = append(sr.errs, err)
+	sr.mu.Unlock()
+}
+
+func TestHandlerConcurrentHandle(t *testing.T) {
+	var (
+		sr syncReporter
+		wg sync.WaitGroup
+
+		// every With call grows the errors of the handler, leaving spare
+		// capacity after the third one
+		l = slog.New(
+			errslog.NewHandler(slog.NewTextHandler(io.Discard, nil), errslog.WithReporter(&sr)),
+		).With("a", io.EOF).With("b", io.EOF).With("c", io.EOF)
+	)
+
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			l.Error("failed", "err", fmt.Errorf("attempt %d", i))
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.Len(t, sr.errs, 16*4)
+
+	seen := make(map[string]struct{})
+
+	for _, err := range sr.errs {
+		seen[err.Error()] = struct{}{}
+	}
+
+	assert.Len(t, seen, 17)
+}
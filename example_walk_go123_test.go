@@ -0,0 +1,28 @@
+//go:build go1.23
+
+package errors_test
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/upfluence/errors"
+)
+
+func ExampleAll() {
+	err := errors.WithSecondaryError(
+		errors.Combine(io.EOF, io.ErrUnexpectedEOF),
+		io.ErrClosedPipe,
+	)
+
+	for p, err := range errors.All(err) {
+		fmt.Printf("%s: %T\n", p, err)
+	}
+	// Output:
+	// /: *secondary.withSecondary
+	// /cause: *stacktrace.withFrame
+	// /cause/cause: multi.multiError
+	// /cause/cause/[0]: *errors.errorString
+	// /cause/cause/[1]: *errors.errorString
+	// /secondary: *errors.errorString
+}
@@ -0,0 +1,20 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/upfluence/errors"
+)
+
+var ErrUserNotFound = errors.Register("example.user_not_found", errors.New("user not found"))
+
+func ExampleDecode() {
+	buf := errors.Encode(errors.Wrapf(ErrUserNotFound, "fetch user %d", 42))
+
+	err := errors.Decode(buf)
+
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, ErrUserNotFound))
+	// Output: fetch user 42: user not found
+	// true
+}
@@ -0,0 +1,13 @@
+package errors
+
+import "github.com/upfluence/errors/fingerprint"
+
+// Fingerprint returns a stable identifier of err, meant to group the
+// occurrences of the same failure, see fingerprint.Of.
+func Fingerprint(err error) string { return fingerprint.Of(err) }
+
+// WithFingerprint overrides the fingerprint of the error with parts joined by
+// colons and adds a stack frame.
+func WithFingerprint(err error, parts ...string) error {
+	return WithFrame(fingerprint.WithFingerprint(err, parts...), 1)
+}
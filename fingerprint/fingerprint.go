@@ -0,0 +1,118 @@
+// Package fingerprint derives stable identifiers from errors, meant to group
+// the occurrences of the same failure.
+//
+// A fingerprint only depends on what identifies the failure and not on the
+// values it carries: the root cause, the registered code, the domain, the
+// message templates and the functions of the stack frames. Formatted
+// arguments and the line numbers of the wrapping frames are ignored, so the
+// fingerprint survives most unrelated edits and does not change from one
+// occurrence to the next.
+package fingerprint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/domain"
+	"github.com/upfluence/errors/message"
+	"github.com/upfluence/errors/registry"
+	"github.com/upfluence/errors/stacktrace"
+	"github.com/upfluence/errors/value"
+)
+
+// Key is the tag key of the fingerprints attached with WithFingerprint.
+const Key = "fingerprint"
+
+// Of returns the fingerprint of err. The outermost fingerprint attached with
+// WithFingerprint is used, otherwise it is computed from:
+//
+//   - the registered code of the error, see registry.CodeOf
+//   - the function creating the root cause, see base.Root, and the template
+//     of its message if it carries a stack frame, as the errors of New and
+//     Newf do, or else the type and the message of the root cause, as for
+//     sentinel errors
+//   - the domain of the error
+//   - the unformatted templates of the messages added along the chain, see
+//     message.GetTemplates
+//   - the function names of the stack frames
+//
+// Returns an empty string if err is nil.
+func Of(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	if fp := override(err); fp != "" {
+		return fp
+	}
+
+	var (
+		h     = sha256.New()
+		cause = base.Root(err)
+	)
+
+	write(h, registry.CodeOf(err))
+
+	if fs := stacktrace.GetFrames(cause); len(fs) > 0 {
+		// the root cause was created by the module, its type is hidden by
+		// the opaque wrapper and its message may carry formatted values:
+		// the function creating it and the template of its message, hashed
+		// with the other templates, identify it
+		fn, _, _ := fs[len(fs)-1].Location()
+		write(h, fn)
+
+		if len(message.GetTemplates(cause)) == 0 {
+			write(h, cause.Error())
+		}
+	} else {
+		write(h, fmt.Sprintf("%T", cause))
+		write(h, cause.Error())
+	}
+
+	write(h, string(domain.GetDomain(err)))
+
+	for _, t := range message.GetTemplates(err) {
+		write(h, t.Format)
+	}
+
+	for _, f := range stacktrace.GetFrames(err) {
+		fn, _, _ := f.Location()
+		write(h, fn)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+type overrideKey struct{}
+
+func (overrideKey) Resolve(err error) (interface{}, bool) {
+	fp := override(err)
+	return fp, fp != ""
+}
+
+// override returns the outermost fingerprint attached with WithFingerprint,
+// errors hiding their chain, like opaque errors, expose the one of their
+// cause.
+func override(err error) string {
+	var fp string
+
+	base.Inspect(err, func(err error) bool {
+		if ferr, ok := err.(interface{ Fingerprint() string }); ok {
+			fp = ferr.Fingerprint()
+		} else if v, ok := value.Own(err, overrideKey{}); ok {
+			fp = v.(string)
+		}
+
+		return fp == ""
+	})
+
+	return fp
+}
+
+func write(w io.Writer, s string) {
+	io.WriteString(w, s)
+	w.Write([]byte{0})
+}
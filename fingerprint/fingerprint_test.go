@@ -0,0 +1,86 @@
+package fingerprint_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/errtest"
+	"github.com/upfluence/errors/fingerprint"
+)
+
+func TestWithFingerprint(t *testing.T) {
+	errtest.TestErrorWrapper(
+		t,
+		func(err error) error { return errors.WithFingerprint(err, "foo") },
+		errtest.ErrorWrapperOptions{N: 2},
+	)
+}
+
+func notFound(id int) error { return errors.Newf("user %d not found", id) }
+
+func fetch(id int) error { return errors.Wrapf(notFound(id), "fetch %d", id) }
+
+func read(err error) error { return errors.Wrap(err, "read") }
+
+func wrap(err error, msg string) error { return errors.Wrap(err, msg) }
+
+func lines() (error, error, error) {
+	a := errors.New("foo")
+	b := errors.New("foo")
+	c := errors.New("bar")
+
+	return a, b, c
+}
+
+func TestOf(t *testing.T) {
+	assert.Equal(t, "", fingerprint.Of(nil))
+
+	lineA, lineB, other := lines()
+
+	for _, tt := range []struct {
+		name string
+		a, b error
+		same bool
+	}{
+		{name: "args", a: fetch(1), b: fetch(2), same: true},
+		{name: "origin", a: fetch(1), b: notFound(1)},
+		{name: "line", a: lineA, b: lineB, same: true},
+		{name: "message", a: lineA, b: other},
+		{name: "template", a: wrap(io.EOF, "read"), b: wrap(io.EOF, "write")},
+		{name: "sentinel", a: read(io.EOF), b: read(io.ErrUnexpectedEOF)},
+		{name: "same sentinel", a: read(io.EOF), b: read(io.EOF), same: true},
+		{name: "domain", a: errors.WithDomain(read(io.EOF), "foo"), b: read(io.EOF)},
+		{
+			name: "override",
+			a:    errors.WithFingerprint(fetch(1), "user", "not_found"),
+			b:    errors.WithFingerprint(read(io.EOF), "user", "not_found"),
+			same: true,
+		},
+		{
+			name: "opaque override",
+			a:    errors.Opaque(errors.WithFingerprint(fetch(1), "user", "not_found")),
+			b:    errors.WithFingerprint(read(io.EOF), "user", "not_found"),
+			same: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := fingerprint.Of(tt.a), fingerprint.Of(tt.b)
+
+			assert.NotEmpty(t, a)
+
+			if tt.same {
+				assert.Equal(t, a, b)
+			} else {
+				assert.NotEqual(t, a, b)
+			}
+		})
+	}
+
+	assert.Equal(t, "user:not_found", errors.Fingerprint(errors.WithFingerprint(io.EOF, "user", "not_found")))
+	assert.Equal(t, io.EOF, fingerprint.WithFingerprint(io.EOF))
+	assert.Contains(t, fmt.Sprintf("%+v", errors.WithFingerprint(io.EOF, "foo")), "fingerprint: foo")
+}
@@ -0,0 +1,46 @@
+package fingerprint
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/internal/logvalue"
+	"github.com/upfluence/errors/stacktrace"
+)
+
+type withFingerprint struct {
+	cause       error
+	fingerprint string
+}
+
+func (wf *withFingerprint) Error() string       { return wf.cause.Error() }
+func (wf *withFingerprint) Unwrap() error       { return wf.cause }
+func (wf *withFingerprint) Cause() error        { return wf.cause }
+func (wf *withFingerprint) Fingerprint() string { return wf.fingerprint }
+
+func (wf *withFingerprint) Format(s fmt.State, verb rune) { base.FormatError(wf, s, verb) }
+func (wf *withFingerprint) LogValue() slog.Value          { return logvalue.Of(wf, stacktrace.Origin(wf)) }
+
+func (wf *withFingerprint) FormatError(p base.Printer) error {
+	p.Printf("fingerprint: %s", wf.fingerprint)
+	return wf.cause
+}
+
+func (wf *withFingerprint) Tags() map[string]interface{} {
+	return map[string]interface{}{Key: wf.fingerprint}
+}
+
+// WithFingerprint overrides the fingerprint of the error with parts joined
+// by colons.
+// Returns err if err is nil or if parts are empty.
+func WithFingerprint(err error, parts ...string) error {
+	fp := strings.Join(parts, ":")
+
+	if err == nil || fp == "" {
+		return err
+	}
+
+	return &withFingerprint{cause: err, fingerprint: fp}
+}
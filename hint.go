@@ -0,0 +1,23 @@
+package errors
+
+import "github.com/upfluence/errors/hint"
+
+// WithHint attaches a hint telling how to recover from the error and adds a
+// stack frame. It does not change Error().
+func WithHint(err error, h string) error {
+	return WithFrame(hint.WithHint(err, h), 1)
+}
+
+// WithDetail attaches a detail giving more context about the error and adds
+// a stack frame. It does not change Error().
+func WithDetail(err error, detail string) error {
+	return WithFrame(hint.WithDetail(err, detail), 1)
+}
+
+// GetHints returns every hint of err, its multi and secondary branches
+// included, de-duplicated.
+func GetHints(err error) []string { return hint.GetHints(err) }
+
+// GetDetails returns every detail of err, its multi and secondary branches
+// included, de-duplicated.
+func GetDetails(err error) []string { return hint.GetDetails(err) }
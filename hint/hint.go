@@ -0,0 +1,144 @@
+// Package hint annotates errors with messages meant for the operators.
+//
+// A hint tells how to recover from the error, a detail gives more context
+// about it. Neither changes Error(), they show up in the verbose %+v
+// rendering, the JSON export and the reports.
+package hint
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/internal/logvalue"
+	"github.com/upfluence/errors/stacktrace"
+	"github.com/upfluence/errors/value"
+)
+
+type withHint struct {
+	cause error
+	hint  string
+}
+
+func (wh *withHint) Error() string { return wh.cause.Error() }
+func (wh *withHint) Unwrap() error { return wh.cause }
+func (wh *withHint) Cause() error  { return wh.cause }
+func (wh *withHint) Hint() string  { return wh.hint }
+
+func (wh *withHint) Format(s fmt.State, verb rune) { base.FormatError(wh, s, verb) }
+func (wh *withHint) LogValue() slog.Value          { return logvalue.Of(wh, stacktrace.Origin(wh)) }
+
+func (wh *withHint) FormatError(p base.Printer) error {
+	p.Printf("hint: %s", wh.hint)
+	return wh.cause
+}
+
+// WithHint attaches a hint telling how to recover from the error.
+// Returns err if err is nil or if hint is empty.
+func WithHint(err error, hint string) error {
+	if err == nil || hint == "" {
+		return err
+	}
+
+	return &withHint{cause: err, hint: hint}
+}
+
+type withDetail struct {
+	cause  error
+	detail string
+}
+
+func (wd *withDetail) Error() string  { return wd.cause.Error() }
+func (wd *withDetail) Unwrap() error  { return wd.cause }
+func (wd *withDetail) Cause() error   { return wd.cause }
+func (wd *withDetail) Detail() string { return wd.detail }
+
+func (wd *withDetail) Format(s fmt.State, verb rune) { base.FormatError(wd, s, verb) }
+func (wd *withDetail) LogValue() slog.Value          { return logvalue.Of(wd, stacktrace.Origin(wd)) }
+
+func (wd *withDetail) FormatError(p base.Printer) error {
+	p.Printf("detail: %s", wd.detail)
+	return wd.cause
+}
+
+// WithDetail attaches a detail giving more context about the error.
+// Returns err if err is nil or if detail is empty.
+func WithDetail(err error, detail string) error {
+	if err == nil || detail == "" {
+		return err
+	}
+
+	return &withDetail{cause: err, detail: detail}
+}
+
+type hintsKey struct{}
+
+func (hintsKey) Resolve(err error) (interface{}, bool) {
+	hs := GetHints(err)
+	return hs, len(hs) > 0
+}
+
+type detailsKey struct{}
+
+func (detailsKey) Resolve(err error) (interface{}, bool) {
+	ds := GetDetails(err)
+	return ds, len(ds) > 0
+}
+
+// GetHints returns every hint of the error tree, the secondary errors
+// included, de-duplicated and in the order of base.Walk. Errors hiding their
+// chain, like opaque errors, expose the hints of their cause.
+func GetHints(err error) []string { return collect(err, OwnHints) }
+
+// GetDetails returns every detail of the error tree, the secondary errors
+// included, de-duplicated and in the order of base.Walk. Errors hiding their
+// chain, like opaque errors, expose the details of their cause.
+func GetDetails(err error) []string { return collect(err, OwnDetails) }
+
+// OwnHints returns the hints attached by err itself, without traversing its
+// chain. Errors hiding their chain return the hints of their cause.
+func OwnHints(err error) []string {
+	if herr, ok := err.(interface{ Hint() string }); ok {
+		return []string{herr.Hint()}
+	}
+
+	hs, _ := value.Own(err, hintsKey{})
+	hss, _ := hs.([]string)
+
+	return hss
+}
+
+// OwnDetails returns the details attached by err itself, without traversing
+// its chain. Errors hiding their chain return the details of their cause.
+func OwnDetails(err error) []string {
+	if derr, ok := err.(interface{ Detail() string }); ok {
+		return []string{derr.Detail()}
+	}
+
+	ds, _ := value.Own(err, detailsKey{})
+	dss, _ := ds.([]string)
+
+	return dss
+}
+
+func collect(err error, fn func(error) []string) []string {
+	var (
+		res  []string
+		seen = make(map[string]struct{})
+	)
+
+	base.Walk(err, func(err error, _ base.Path) base.WalkAction {
+		for _, v := range fn(err) {
+			if _, ok := seen[v]; ok || v == "" {
+				continue
+			}
+
+			seen[v] = struct{}{}
+			res = append(res, v)
+		}
+
+		return base.WalkContinue
+	})
+
+	return res
+}
@@ -0,0 +1,53 @@
+package hint_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/errtest"
+)
+
+func TestWithHint(t *testing.T) {
+	errtest.TestErrorWrapper(
+		t,
+		func(err error) error { return errors.WithHint(err, "foo") },
+		errtest.ErrorWrapperOptions{N: 2},
+	)
+}
+
+func TestWithDetail(t *testing.T) {
+	errtest.TestErrorWrapper(
+		t,
+		func(err error) error { return errors.WithDetail(err, "foo") },
+		errtest.ErrorWrapperOptions{N: 2},
+	)
+}
+
+func TestGetHints(t *testing.T) {
+	err := errors.WithSecondaryError(
+		errors.Combine(
+			errors.WithHint(errors.WithHint(io.EOF, "check the DSN"), "retry later"),
+			errors.Opaque(errors.WithHint(errors.WithDetail(io.EOF, "pool exhausted"), "check the DSN")),
+		),
+		errors.WithDetail(errors.WithHint(io.EOF, "close the file"), "pool exhausted"),
+	)
+
+	assert.Equal(
+		t,
+		[]string{"retry later", "check the DSN", "close the file"},
+		errors.GetHints(err),
+	)
+	assert.Equal(t, []string{"pool exhausted"}, errors.GetDetails(err))
+	assert.Nil(t, errors.GetHints(io.EOF))
+	assert.Nil(t, errors.GetDetails(nil))
+
+	out := fmt.Sprintf("%+v", err)
+
+	assert.Contains(t, out, "hint: retry later")
+	assert.Contains(t, out, "detail: pool exhausted")
+	assert.Equal(t, "EOF", errors.WithHint(io.EOF, "foo").Error())
+}
@@ -0,0 +1,110 @@
+// Package logvalue builds the log/slog representation shared by the error
+// wrappers of the module.
+//
+// It only depends on the base and internal/sensitive packages, so every
+// wrapper package can use it without introducing import cycles. The metadata
+// is read through the same method sets as the extraction helpers of the
+// module.
+package logvalue
+
+import (
+	"log/slog"
+	"sort"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/internal/sensitive"
+)
+
+// Locator resolves a stack frame location, it is implemented by
+// stacktrace.Frame.
+type Locator interface {
+	Location() (string, string, int)
+}
+
+// Of returns a group value describing err: its message, domain, status,
+// tags and the location where it originated.
+func Of(err error, origin Locator) slog.Value {
+	var (
+		ts    = tags(err)
+		attrs = []slog.Attr{slog.String("message", err.Error())}
+	)
+
+	if d, ok := ts["domain"]; ok {
+		attrs = append(attrs, slog.Any("domain", d))
+		delete(ts, "domain")
+	}
+
+	if s, ok := status(err); ok {
+		attrs = append(attrs, slog.String("status", s))
+	}
+
+	delete(ts, "status")
+
+	if len(ts) > 0 {
+		ks := make([]string, 0, len(ts))
+
+		for k := range ts {
+			ks = append(ks, k)
+		}
+
+		sort.Strings(ks)
+
+		tattrs := make([]slog.Attr, len(ks))
+
+		for i, k := range ks {
+			if sensitive.IsKey(k) {
+				tattrs[i] = slog.String(k, sensitive.Redacted)
+			} else {
+				tattrs[i] = slog.Any(k, ts[k])
+			}
+		}
+
+		attrs = append(attrs, slog.Attr{Key: "tags", Value: slog.GroupValue(tattrs...)})
+	}
+
+	if origin != nil {
+		if fn, file, line := origin.Location(); fn != "" {
+			attrs = append(
+				attrs,
+				slog.Group(
+					"origin",
+					slog.String("function", fn),
+					slog.String("file", file),
+					slog.Int("line", line),
+				),
+			)
+		}
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+func tags(err error) map[string]interface{} {
+	res := make(map[string]interface{})
+
+	base.Inspect(err, func(err error) bool {
+		if t, ok := err.(interface{ Tags() map[string]interface{} }); ok {
+			for k, v := range t.Tags() {
+				if _, ok := res[k]; !ok {
+					res[k] = v
+				}
+			}
+		}
+
+		return true
+	})
+
+	return res
+}
+
+func status(err error) (st string, found bool) {
+	base.Inspect(err, func(err error) bool {
+		if s, ok := err.(interface{ Status() string }); ok {
+			st, found = s.Status(), true
+		}
+
+		return !found
+	})
+
+	return st, found
+}
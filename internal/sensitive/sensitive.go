@@ -0,0 +1,35 @@
+// Package sensitive holds the registry of the sensitive tag keys.
+//
+// It only depends on the standard library, so every output path of the
+// module, internal/logvalue included, can consult it without introducing
+// import cycles.
+package sensitive
+
+import "sync"
+
+// Redacted replaces the sensitive values in every output of the module.
+const Redacted = "[REDACTED]"
+
+var (
+	keysMu sync.RWMutex
+	keys   = map[string]struct{}{}
+)
+
+// Register marks every value attached under one of ks as sensitive.
+func Register(ks ...string) {
+	keysMu.Lock()
+	defer keysMu.Unlock()
+
+	for _, k := range ks {
+		keys[k] = struct{}{}
+	}
+}
+
+// IsKey reports whether k was registered with Register.
+func IsKey(k string) bool {
+	keysMu.RLock()
+	_, ok := keys[k]
+	keysMu.RUnlock()
+
+	return ok
+}
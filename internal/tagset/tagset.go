@@ -0,0 +1,40 @@
+// Package tagset collects the tags attached along an error tree.
+//
+// It is the traversal of tags.GetTags without the computed tags, meant for
+// the errors merging the tags of the errors they hold, like multi errors,
+// secondary errors and opaque errors.
+package tagset
+
+import "github.com/upfluence/errors/base"
+
+// Collect returns the tags exposed through Tags() map[string]interface{}
+// by the errors of the tree of err, the outermost value wins, see
+// base.Inspect for the precedence rule.
+// Returns nil if no tags are found.
+func Collect(err error) map[string]interface{} {
+	var tags map[string]interface{}
+
+	base.Inspect(err, func(err error) bool {
+		t, ok := err.(interface{ Tags() map[string]interface{} })
+
+		if !ok {
+			return true
+		}
+
+		ts := t.Tags()
+
+		if len(ts) > 0 && tags == nil {
+			tags = make(map[string]interface{}, len(ts))
+		}
+
+		for k, v := range ts {
+			if _, ok := tags[k]; !ok {
+				tags[k] = v
+			}
+		}
+
+		return true
+	})
+
+	return tags
+}
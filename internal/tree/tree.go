@@ -0,0 +1,197 @@
+// Package tree walks error chains into a neutral representation shared by
+// the encodings of the module.
+//
+// The errjson and wire packages only differ by the names of their keys and
+// the metadata they keep, both build their nodes from the Node returned by
+// Encode.
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/domain"
+	"github.com/upfluence/errors/hint"
+	"github.com/upfluence/errors/registry"
+	"github.com/upfluence/errors/stacktrace"
+	"github.com/upfluence/errors/tags"
+)
+
+// Node describes one layer of an error chain, it holds the metadata
+// attached by the layer only.
+type Node struct {
+	// Type is the Go type of the layer, or the original type exposed by a
+	// decoded error through TypeName() string.
+	Type string
+
+	// Code is the code the layer is registered under, see the registry
+	// package.
+	Code string
+
+	// Message is the message added by a wrapper layer, it is nil for the
+	// layers adding none. Text is the full message of a root cause or of a
+	// foreign wrapper.
+	Message *string
+	Text    string
+
+	Frames []Frame
+	Tags   map[string]interface{}
+	Domain string
+	Status string
+
+	// Sensitive lists the keys of the sensitive tags, see tags.IsSensitive,
+	// sorted. Their values are kept revealed in Tags, each encoding decides
+	// whether to mask them.
+	Sensitive []string
+
+	Hints   []string
+	Details []string
+
+	Errors    [][]Node
+	Secondary []Node
+}
+
+// Frame is a stack frame resolved at encoding time.
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// Encode returns the nodes of the chain of err, from the outermost layer to
+// the root cause. The branches of the multi errors and the secondary errors
+// are encoded as nested chains.
+func Encode(err error) []Node {
+	var ns []Node
+
+	for err != nil {
+		var n Node
+
+		n, err = encodeNode(err)
+		ns = append(ns, n)
+	}
+
+	return ns
+}
+
+func encodeNode(err error) (Node, error) {
+	var (
+		n = Node{Type: typeName(err)}
+
+		next     = base.UnwrapOnce(err)
+		branched bool
+	)
+
+	n.Code, _ = registry.Lookup(err)
+
+	switch merr := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, cerr := range merr.Unwrap() {
+			n.Errors = append(n.Errors, Encode(cerr))
+		}
+
+		branched = true
+	case interface{ SecondaryError() error }:
+		n.Secondary = Encode(merr.SecondaryError())
+		branched = true
+	}
+
+	switch merr := err.(type) {
+	case interface{ RemoteText() string }:
+		n.Text = merr.RemoteText()
+	case interface{ Message() string }:
+		msg := merr.Message()
+		n.Message = &msg
+	case base.Formatter:
+		if next == nil && !branched {
+			n.Text = err.Error()
+		}
+	default:
+		n.Text = err.Error()
+	}
+
+	switch ferr := err.(type) {
+	case interface{ Frame() stacktrace.Frame }:
+		n.Frames = encodeFrames([]stacktrace.Frame{ferr.Frame()})
+	case interface{ Frames() []stacktrace.Frame }:
+		n.Frames = encodeFrames(ferr.Frames())
+	case interface{ RemoteFrames() []Frame }:
+		n.Frames = ferr.RemoteFrames()
+	}
+
+	if derr, ok := err.(interface{ Domain() domain.Domain }); ok {
+		if d := derr.Domain(); d != domain.NoDomain {
+			n.Domain = string(d)
+		}
+	}
+
+	if serr, ok := err.(interface{ Status() string }); ok {
+		n.Status = serr.Status()
+	}
+
+	n.Hints = hint.OwnHints(err)
+	n.Details = hint.OwnDetails(err)
+
+	if terr, ok := err.(interface{ Tags() map[string]interface{} }); ok && !branched {
+		n.Tags, n.Sensitive = encodeTags(terr.Tags(), n)
+	}
+
+	return n, next
+}
+
+func typeName(err error) string {
+	if rerr, ok := err.(interface{ TypeName() string }); ok {
+		return rerr.TypeName()
+	}
+
+	return fmt.Sprintf("%T", err)
+}
+
+func encodeFrames(fs []stacktrace.Frame) []Frame {
+	res := make([]Frame, len(fs))
+
+	for i, f := range fs {
+		fn, file, line := f.Location()
+		res[i] = Frame{Function: fn, File: file, Line: line}
+	}
+
+	return res
+}
+
+// encodeTags drops the tags duplicating the domain and the status of the
+// node, reveals the sensitive values and replaces the values that can not be
+// encoded as JSON by their fmt %v representation. It also returns the keys
+// of the sensitive values.
+func encodeTags(ts map[string]interface{}, n Node) (map[string]interface{}, []string) {
+	var (
+		res       map[string]interface{}
+		sensitive []string
+	)
+
+	for k, v := range ts {
+		if (k == "domain" && n.Domain != "") || (k == "status" && n.Status != "") {
+			continue
+		}
+
+		if res == nil {
+			res = make(map[string]interface{}, len(ts))
+		}
+
+		if tags.IsSensitive(k, v) {
+			sensitive = append(sensitive, k)
+			v = tags.Reveal(v)
+		}
+
+		if _, err := json.Marshal(v); err != nil {
+			v = fmt.Sprintf("%v", v)
+		}
+
+		res[k] = v
+	}
+
+	sort.Strings(sensitive)
+
+	return res, sensitive
+}
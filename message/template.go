@@ -0,0 +1,120 @@
+package message
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/value"
+)
+
+// Template is an unformatted message and its arguments. Format is always a
+// valid format string, the messages added without arguments are escaped.
+type Template struct {
+	Format string
+	Args   []interface{}
+}
+
+func (t Template) String() string { return fmt.Sprintf(t.Format, t.Args...) }
+
+type templater interface {
+	Template() string
+	Args() []interface{}
+}
+
+func newTemplate(t templater) Template {
+	if args := t.Args(); len(args) > 0 {
+		return Template{Format: t.Template(), Args: args}
+	}
+
+	return Template{Format: escape(t.Template())}
+}
+
+func escape(s string) string { return strings.ReplaceAll(s, "%", "%%") }
+
+type templatesKey struct{}
+
+func (templatesKey) Resolve(err error) (interface{}, bool) {
+	ts := GetTemplates(err)
+	return ts, len(ts) > 0
+}
+
+// GetTemplates returns the template of every message added along the error
+// chain, outermost first, the one of the root cause included if it was
+// created by Newf. Only the first branch of the multi errors is followed,
+// see base.InspectPath. Errors hiding their chain, like opaque errors,
+// expose the templates of their cause.
+func GetTemplates(err error) []Template {
+	var ts []Template
+
+	base.InspectPath(err, func(err error) bool {
+		if terr, ok := err.(templater); ok {
+			ts = append(ts, newTemplate(terr))
+		} else if v, ok := value.Own(err, templatesKey{}); ok {
+			ts = append(ts, v.([]Template)...)
+		}
+
+		return true
+	})
+
+	return ts
+}
+
+type templateKey struct{}
+
+func (templateKey) Resolve(err error) (interface{}, bool) { return GetTemplate(err), true }
+
+// GetTemplate returns the template of the whole message of err: formatting
+// it gives err.Error(). The messages added along the chain keep their
+// template, the rest of the message is escaped verbatim. Errors hiding their
+// chain, like opaque errors, expose the template of their cause.
+// Returns the zero Template if err is nil.
+func GetTemplate(err error) Template {
+	var errs []error
+
+	base.InspectPath(err, func(err error) bool {
+		errs = append(errs, err)
+		return true
+	})
+
+	var (
+		b    strings.Builder
+		args []interface{}
+	)
+
+	for i, err := range errs {
+		var next error
+
+		if i < len(errs)-1 {
+			next = errs[i+1]
+		}
+
+		if terr, ok := err.(templater); ok {
+			t := newTemplate(terr)
+
+			b.WriteString(t.Format)
+			args = append(args, t.Args...)
+
+			if next == nil {
+				// a root cause templating its whole message
+				break
+			}
+
+			b.WriteString(": ")
+		} else if v, ok := value.Own(err, templateKey{}); ok {
+			t := v.(Template)
+
+			b.WriteString(t.Format)
+			args = append(args, t.Args...)
+
+			break
+		} else if next == nil || next.Error() != err.Error() {
+			// the layer alters the message in a way that can not be
+			// templated, its whole message is used
+			b.WriteString(escape(err.Error()))
+			break
+		}
+	}
+
+	return Template{Format: b.String(), Args: args}
+}
@@ -0,0 +1,82 @@
+package message_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/message"
+)
+
+func TestGetTemplates(t *testing.T) {
+	err := errors.Wrapf(errors.Wrap(io.EOF, "read 100%"), "fetch user %d", 42)
+
+	assert.Equal(
+		t,
+		[]message.Template{
+			{Format: "fetch user %d", Args: []interface{}{42}},
+			{Format: "read 100%%"},
+		},
+		message.GetTemplates(err),
+	)
+	assert.Nil(t, message.GetTemplates(io.EOF))
+	assert.Equal(
+		t,
+		[]message.Template{
+			{Format: "fetch"},
+			{Format: "user %d not found", Args: []interface{}{42}},
+		},
+		message.GetTemplates(errors.Wrap(errors.Newf("user %d not found", 42), "fetch")),
+	)
+	assert.Equal(
+		t,
+		[]message.Template{{Format: "fetch"}, {Format: "read"}},
+		message.GetTemplates(
+			errors.Wrap(errors.Combine(errors.Wrap(io.EOF, "read"), errors.Wrap(io.EOF, "write")), "fetch"),
+		),
+	)
+}
+
+func TestGetTemplate(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want message.Template
+	}{
+		{name: "nil"},
+		{name: "leaf", err: fmt.Errorf("100%%"), want: message.Template{Format: "100%%"}},
+		{
+			name: "wrapped",
+			err:  errors.Wrapf(errors.WithStatus(io.EOF, "foo"), "user %d, %s", 42, "bar"),
+			want: message.Template{Format: "user %d, %s: EOF", Args: []interface{}{42, "bar"}},
+		},
+		{
+			name: "foreign wrapper",
+			err:  errors.Wrapf(fmt.Errorf("foo: %w", errors.Wrapf(io.EOF, "%d", 1)), "user %d", 42),
+			want: message.Template{Format: "user %d: foo: 1: EOF", Args: []interface{}{42}},
+		},
+		{
+			name: "formatted root",
+			err:  errors.Wrap(errors.Newf("user %d not found", 42), "fetch"),
+			want: message.Template{Format: "fetch: user %d not found", Args: []interface{}{42}},
+		},
+		{
+			name: "multi",
+			err:  errors.Wrapf(errors.Combine(errors.Wrapf(io.EOF, "%d", 1), io.EOF), "user %d", 42),
+			want: message.Template{Format: "user %d: [1: EOF, EOF]", Args: []interface{}{42}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := message.GetTemplate(tt.err)
+
+			assert.Equal(t, tt.want, got)
+
+			if tt.err != nil {
+				assert.Equal(t, tt.err.Error(), got.String())
+			}
+		})
+	}
+}
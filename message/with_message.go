@@ -5,7 +5,14 @@
 // to the original error message, creating a clear error chain.
 package message
 
-import "fmt"
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/internal/logvalue"
+	"github.com/upfluence/errors/stacktrace"
+)
 
 type withMessage struct {
 	cause error
@@ -14,20 +21,58 @@ type withMessage struct {
 	args []interface{}
 }
 
-func (wm *withMessage) Error() string {
-	var msg = wm.fmt
-
+// Message returns the message of this layer alone, without the message of
+// the cause.
+func (wm *withMessage) Message() string {
 	if len(wm.args) > 0 {
-		msg = fmt.Sprintf(msg, wm.args...)
+		return fmt.Sprintf(wm.fmt, wm.args...)
 	}
 
-	return msg + ": " + wm.cause.Error()
+	return wm.fmt
 }
 
+func (wm *withMessage) Error() string { return wm.Message() + ": " + wm.cause.Error() }
+
 func (wm *withMessage) Unwrap() error       { return wm.cause }
 func (wm *withMessage) Cause() error        { return wm.cause }
+func (wm *withMessage) Template() string    { return wm.fmt }
 func (wm *withMessage) Args() []interface{} { return wm.args }
 
+func (wm *withMessage) Format(s fmt.State, verb rune) { base.FormatError(wm, s, verb) }
+func (wm *withMessage) LogValue() slog.Value          { return logvalue.Of(wm, stacktrace.Origin(wm)) }
+
+func (wm *withMessage) FormatError(p base.Printer) error {
+	p.Printf("%s", wm.Message())
+	return wm.cause
+}
+
+type formatted struct {
+	msg string
+
+	fmt  string
+	args []interface{}
+}
+
+func (f *formatted) Error() string { return f.msg }
+
+func (f *formatted) Template() string {
+	if len(f.args) > 0 {
+		return f.fmt
+	}
+
+	// the format was applied, the message is the template to escape
+	return f.msg
+}
+
+func (f *formatted) Args() []interface{} { return f.args }
+
+// Newf returns an error whose message is formatted like fmt.Sprintf, it
+// keeps the unformatted template of its message, see GetTemplate. Unlike
+// fmt.Errorf, the %w verb does not wrap the errors.
+func Newf(msg string, args ...interface{}) error {
+	return &formatted{msg: fmt.Sprintf(msg, args...), fmt: msg, args: args}
+}
+
 // WithMessage wraps an error with an additional context message.
 // Returns nil if err is nil.
 func WithMessage(err error, msg string) error {
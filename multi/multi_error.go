@@ -8,10 +8,14 @@ package multi
 
 import (
 	"errors"
+	"fmt"
+	"log/slog"
 	"strings"
 
 	"github.com/upfluence/errors/base"
-	"github.com/upfluence/errors/tags"
+	"github.com/upfluence/errors/internal/logvalue"
+	"github.com/upfluence/errors/internal/tagset"
+	"github.com/upfluence/errors/stacktrace"
 )
 
 type multiError []error
@@ -37,11 +41,22 @@ func (errs multiError) Error() string {
 	return b.String()
 }
 
+func (errs multiError) Format(s fmt.State, verb rune) { base.FormatError(errs, s, verb) }
+func (errs multiError) LogValue() slog.Value          { return logvalue.Of(errs, stacktrace.Origin(errs)) }
+
+func (errs multiError) FormatError(p base.Printer) error {
+	for i, err := range errs {
+		p.Branch(fmt.Sprintf("[%d]", i), err)
+	}
+
+	return nil
+}
+
 func (errs multiError) Tags() map[string]interface{} {
 	var allTags map[string]interface{}
 
 	for _, err := range errs {
-		ts := tags.GetTags(err)
+		ts := tagset.Collect(err)
 
 		if len(ts) > 0 && allTags == nil {
 			allTags = make(map[string]interface{}, len(ts))
@@ -98,30 +113,42 @@ type MultiError interface {
 	Errors() []error
 }
 
-// ExtractErrors recursively extracts all errors from an error,
-// flattening any MultiError instances. Returns nil if err is nil.
+// ExtractErrors returns the errors held by the first MultiError of the
+// chain of err, or err alone if its chain holds none. The branches of the
+// other errors implementing Unwrap() []error and the secondary errors are
+// not searched. Returns nil if err is nil.
 func ExtractErrors(err error) []error {
 	if err == nil {
 		return nil
 	}
 
-	merr, ok := err.(MultiError)
+	var errs []error
 
-	if ok {
-		return merr.Errors()
-	}
+	cause := err
 
-	nerr := base.UnwrapOnce(err)
+	base.Walk(err, func(err error, p base.Path) base.WalkAction {
+		if len(p) > 0 && p[len(p)-1].Kind != base.ChainStep {
+			return base.WalkSkip
+		}
 
-	if nerr == nil {
-		return []error{err}
-	}
+		if merr, ok := err.(MultiError); ok {
+			errs = merr.Errors()
+			return base.WalkStop
+		}
 
-	errs := ExtractErrors(nerr)
+		cause = err
 
-	if len(errs) == 1 && errors.Is(errs[0], nerr) {
+		return base.WalkContinue
+	})
+
+	switch {
+	case errs != nil:
+		return errs
+	case !errors.Is(cause, cause):
+		// a root cause errors.Is can not match, as a non comparable one, is
+		// returned without its wrappers
+		return []error{cause}
+	default:
 		return []error{err}
 	}
-
-	return errs
 }
@@ -1,6 +1,8 @@
 package multi_test
 
 import (
+	"fmt"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -53,6 +55,40 @@ func TestCombining(t *testing.T) {
 	}
 }
 
+func TestExtractErrors(t *testing.T) {
+	foo := errors.New("foo")
+
+	for _, tt := range []struct {
+		name string
+		err  error
+		want []error
+	}{
+		{name: "nil"},
+		{name: "leaf", err: foo, want: []error{foo}},
+		{name: "multi", err: multi.Combine(foo, io.EOF), want: []error{foo, io.EOF}},
+		{name: "wrapped multi", err: errors.Wrap(multi.Combine(foo, io.EOF), "bar"), want: []error{foo, io.EOF}},
+		{
+			name: "nested in a branch",
+			err:  fmt.Errorf("%w, %w", multi.Combine(foo, io.EOF), io.ErrUnexpectedEOF),
+		},
+		{
+			name: "wrapped secondary",
+			err:  errors.Wrap(errors.WithSecondaryError(foo, io.EOF), "bar"),
+			want: []error{foo, io.EOF},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.want
+
+			if want == nil && tt.err != nil {
+				want = []error{tt.err}
+			}
+
+			assert.Equal(t, want, multi.ExtractErrors(tt.err))
+		})
+	}
+}
+
 func TestTags(t *testing.T) {
 	var err = errors.Combine(
 		errors.WithTags(errors.New("foo"), map[string]interface{}{"foo": 1}),
@@ -62,9 +98,10 @@ func TestTags(t *testing.T) {
 	assert.Equal(
 		t,
 		map[string]interface{}{
-			"bar":    2,
-			"domain": "github.com/upfluence/errors/multi_test",
-			"foo":    1,
+			"bar":         2,
+			"domain":      "github.com/upfluence/errors/multi_test",
+			"fingerprint": errors.Fingerprint(err),
+			"foo":         1,
 		},
 		tags.GetTags(err),
 	)
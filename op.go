@@ -0,0 +1,17 @@
+package errors
+
+import "github.com/upfluence/errors/op"
+
+// WithOp records that the error went through the logical operation name, like
+// "user.Fetch", and adds a stack frame. It does not change Error().
+func WithOp(err error, name string) error {
+	return WithFrame(op.WithOp(err, name), 1)
+}
+
+// Ops returns the operations recorded along the chain of err, outermost
+// first.
+func Ops(err error) []string { return op.Ops(err) }
+
+// OpTrail renders err as its op path followed by the message of its root
+// cause, like "user.Fetch > db.Query: connection refused".
+func OpTrail(err error) string { return op.Trail(err) }
@@ -0,0 +1,111 @@
+// Package op annotates errors with the logical operations they went through.
+//
+// The operations recorded along the chain form the op path of the error,
+// outermost first, like "user.Fetch > db.Query". The op path is exposed as
+// the Key tag, so it reaches the logs, the JSON export and the reports
+// without a dedicated traversal.
+package op
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/internal/logvalue"
+	"github.com/upfluence/errors/stacktrace"
+	"github.com/upfluence/errors/value"
+)
+
+const (
+	// Key is the tag key of the op path.
+	Key = "op"
+
+	// Separator joins the operations of the op path.
+	Separator = " > "
+)
+
+type withOp struct {
+	cause error
+	op    string
+}
+
+func (wo *withOp) Error() string { return wo.cause.Error() }
+func (wo *withOp) Unwrap() error { return wo.cause }
+func (wo *withOp) Cause() error  { return wo.cause }
+func (wo *withOp) Op() string    { return wo.op }
+
+func (wo *withOp) Tags() map[string]interface{} {
+	return map[string]interface{}{Key: Path(wo)}
+}
+
+func (wo *withOp) Format(s fmt.State, verb rune) { base.FormatError(wo, s, verb) }
+func (wo *withOp) LogValue() slog.Value          { return logvalue.Of(wo, stacktrace.Origin(wo)) }
+
+func (wo *withOp) FormatError(p base.Printer) error {
+	p.Printf("op: %s", wo.op)
+	return wo.cause
+}
+
+// WithOp records that the error went through the operation op, it does not
+// change Error().
+// Returns err if err is nil or if op is empty.
+func WithOp(err error, op string) error {
+	if err == nil || op == "" {
+		return err
+	}
+
+	return &withOp{cause: err, op: op}
+}
+
+type opsKey struct{}
+
+func (opsKey) Resolve(err error) (interface{}, bool) {
+	ops := Ops(err)
+	return ops, len(ops) > 0
+}
+
+// Ops returns the operations recorded along the chain of err, outermost
+// first. Only the first branch of the multi errors is followed, so the
+// operations describe a single call path, see base.InspectPath. Errors
+// hiding their chain, like opaque errors, expose the operations of their
+// cause.
+func Ops(err error) []string {
+	var ops []string
+
+	base.InspectPath(err, func(err error) bool {
+		if oerr, ok := err.(interface{ Op() string }); ok {
+			ops = append(ops, oerr.Op())
+		} else if v, ok := value.Own(err, opsKey{}); ok {
+			ops = append(ops, v.([]string)...)
+		}
+
+		return true
+	})
+
+	return ops
+}
+
+// Path returns the op path of err, its operations joined with Separator.
+// Returns an empty string if err went through no operation.
+func Path(err error) string {
+	return strings.Join(Ops(err), Separator)
+}
+
+// Trail renders err as its op path followed by the message of its root
+// cause, like "user.Fetch > db.Query: connection refused". The messages
+// added along the chain are left out. Returns err.Error() if err went
+// through no operation, and an empty string if err is nil.
+func Trail(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	p := Path(err)
+
+	if p == "" {
+		return err.Error()
+	}
+
+	return p + ": " + base.Root(err).Error()
+}
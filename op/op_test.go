@@ -0,0 +1,52 @@
+package op_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/errtest"
+	"github.com/upfluence/errors/op"
+	"github.com/upfluence/errors/stacktrace"
+	"github.com/upfluence/errors/tags"
+)
+
+func TestWithOp(t *testing.T) {
+	errtest.TestErrorWrapper(
+		t,
+		func(err error) error { return errors.WithOp(err, "foo.Bar") },
+		errtest.ErrorWrapperOptions{N: 2},
+	)
+
+	assert.Nil(t, op.WithOp(nil, "foo.Bar"))
+	assert.Equal(t, io.EOF, op.WithOp(io.EOF, ""))
+}
+
+func TestOps(t *testing.T) {
+	err := errors.WithOp(
+		errors.Wrap(errors.WithOp(io.ErrUnexpectedEOF, "db.Query"), "fetching"),
+		"user.Fetch",
+	)
+
+	assert.Equal(t, []string{"user.Fetch", "db.Query"}, op.Ops(err))
+	assert.Equal(t, "user.Fetch > db.Query", op.Path(err))
+	assert.Equal(t, "user.Fetch > db.Query: unexpected EOF", op.Trail(err))
+	assert.Equal(t, "fetching: unexpected EOF", err.Error())
+	assert.Len(t, stacktrace.GetFrames(err), 3)
+
+	v, ok := tags.Get(err, tags.NewKey[string](op.Key))
+
+	assert.True(t, ok)
+	assert.Equal(t, "user.Fetch > db.Query", v)
+
+	oerr := errors.Opaque(err)
+
+	assert.Equal(t, []string{"user.Fetch", "db.Query"}, op.Ops(oerr))
+	assert.Equal(t, "user.Fetch > db.Query", tags.GetTags(oerr)[op.Key])
+
+	assert.Nil(t, op.Ops(io.EOF))
+	assert.Equal(t, "EOF", op.Trail(io.EOF))
+	assert.Equal(t, "", op.Trail(nil))
+}
@@ -4,3 +4,15 @@ import "github.com/upfluence/errors/opaque"
 
 // Opaque wraps an error to prevent unwrapping, hiding the underlying error chain.
 func Opaque(err error) error { return opaque.Opaque(err) }
+
+// OpaqueExcept wraps an error to prevent unwrapping like Opaque, but keeps
+// the sentinel errors of allowed matchable by Is.
+func OpaqueExcept(err error, allowed ...error) error {
+	return opaque.OpaqueExcept(err, allowed...)
+}
+
+// OpaqueWith wraps an error to prevent unwrapping like Opaque, the options
+// select what stays visible through it.
+func OpaqueWith(err error, opts ...opaque.Option) error {
+	return opaque.OpaqueWith(err, opts...)
+}
@@ -8,31 +8,56 @@
 package opaque
 
 import (
+	"fmt"
+	"log/slog"
+
+	"github.com/upfluence/errors/base"
 	"github.com/upfluence/errors/domain"
+	"github.com/upfluence/errors/internal/logvalue"
+	"github.com/upfluence/errors/internal/tagset"
 	"github.com/upfluence/errors/stacktrace"
-	"github.com/upfluence/errors/tags"
+	"github.com/upfluence/errors/value"
 )
 
 type opaqueError struct {
 	cause error
+	opts  options
 }
 
 func (oe *opaqueError) Error() string { return oe.cause.Error() }
 
+func (oe *opaqueError) Format(s fmt.State, verb rune) { base.FormatError(oe, s, verb) }
+func (oe *opaqueError) LogValue() slog.Value          { return logvalue.Of(oe, stacktrace.Origin(oe)) }
+
+// FormatError lets the verbose rendering describe the hidden chain, the
+// cause is still not reachable through Unwrap.
+func (oe *opaqueError) FormatError(base.Printer) error { return oe.cause }
+
 func (oe *opaqueError) Domain() domain.Domain {
 	return domain.GetDomain(oe.cause)
 }
 
 func (oe *opaqueError) Tags() map[string]interface{} {
-	return tags.GetTags(oe.cause)
+	return tagset.Collect(oe.cause)
 }
 
 func (oe *opaqueError) Frames() []stacktrace.Frame {
 	return stacktrace.GetFrames(oe.cause)
 }
 
+func (oe *opaqueError) StackTrace() stacktrace.StackTrace {
+	return stacktrace.GetStackTrace(oe.cause)
+}
+
+func (oe *opaqueError) Value(key interface{}) (interface{}, bool) {
+	return value.Lookup(oe.cause, key)
+}
+
 // Opaque wraps an error to make it opaque, preventing type assertions
-// while preserving metadata like domain, tags, and stacktrace.
+// while preserving metadata like domain, tags, stacktrace and the values of
+// the value package. The packages of the module computing their metadata,
+// like the codes, the operations or the hints, expose it as values, see
+// value.Resolver.
 func Opaque(err error) error {
 	return &opaqueError{cause: err}
 }
@@ -17,8 +17,9 @@ func TestOpaque(t *testing.T) {
 	assert.Equal(
 		t,
 		map[string]interface{}{
-			"domain": "github.com/upfluence/errors/opaque_test",
-			"foo":    1,
+			"domain":      "github.com/upfluence/errors/opaque_test",
+			"fingerprint": errors.Fingerprint(err),
+			"foo":         1,
 		},
 		tags.GetTags(err),
 	)
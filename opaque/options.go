@@ -0,0 +1,151 @@
+package opaque
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/upfluence/errors/stats"
+)
+
+// Option configures the opaque errors built by OpaqueWith.
+type Option func(*options)
+
+type options struct {
+	allowed      []error
+	allowedTypes []reflect.Type
+
+	forwardTimeout bool
+	forwardStatus  bool
+}
+
+// Except keeps the errors of allowed matchable by errors.Is, when the
+// opaque error wraps them.
+func Except(allowed ...error) Option {
+	return func(o *options) { o.allowed = append(o.allowed, allowed...) }
+}
+
+// ExceptTypes keeps the errors of the types of allowed matchable by
+// errors.Is and errors.As, when the opaque error wraps them. The values of
+// allowed only stand for their type, typed nil pointers do:
+//
+//	ExceptTypes((*os.PathError)(nil))
+func ExceptTypes(allowed ...error) Option {
+	return func(o *options) {
+		for _, err := range allowed {
+			o.allowedTypes = append(o.allowedTypes, reflect.TypeOf(err))
+		}
+	}
+}
+
+// ForwardTimeout makes the opaque error report the Timeout() of its cause.
+func ForwardTimeout(o *options) { o.forwardTimeout = true }
+
+// ForwardStatus makes the opaque error report the status of its cause, see
+// stats.LookupStatus, instead of falling back to its message. When the
+// cause carries no status the fallback of stats.GetStatus applies.
+func ForwardStatus(o *options) { o.forwardStatus = true }
+
+func (o options) isAllowed(target error) bool {
+	for _, err := range o.allowed {
+		if err == target {
+			return true
+		}
+	}
+
+	return o.isAllowedType(reflect.TypeOf(target))
+}
+
+func (o options) isAllowedType(t reflect.Type) bool {
+	for _, at := range o.allowedTypes {
+		if at == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (oe *opaqueError) Is(target error) bool {
+	return oe.opts.isAllowed(target) && errors.Is(oe.cause, target)
+}
+
+func (oe *opaqueError) As(target interface{}) bool {
+	t := reflect.TypeOf(target)
+
+	return t != nil &&
+		t.Kind() == reflect.Ptr &&
+		oe.opts.isAllowedType(t.Elem()) &&
+		errors.As(oe.cause, target)
+}
+
+// timeoutOpaqueError forwards the Timeout() of its cause. It is a distinct
+// type as errors.As stops at the first error having a Timeout method, which
+// would hide the timeouts of the sibling branches of a multi error.
+type timeoutOpaqueError struct {
+	*opaqueError
+}
+
+func (toe timeoutOpaqueError) Timeout() bool {
+	var terr interface{ Timeout() bool }
+
+	return errors.As(toe.cause, &terr) && terr.Timeout()
+}
+
+// statusOpaqueError forwards the status of its cause. It is a distinct type
+// as stats.GetStatus stops at the first error having a Status method, and
+// it is only used when the cause carries a status.
+type statusOpaqueError struct {
+	*opaqueError
+
+	status string
+}
+
+func (soe statusOpaqueError) Status() string { return soe.status }
+
+type timeoutStatusOpaqueError struct {
+	timeoutOpaqueError
+
+	status string
+}
+
+func (tsoe timeoutStatusOpaqueError) Status() string { return tsoe.status }
+
+// OpaqueWith wraps an error to make it opaque like Opaque, the options
+// select what stays visible through it.
+func OpaqueWith(err error, opts ...Option) error {
+	oe := &opaqueError{cause: err}
+
+	for _, opt := range opts {
+		opt(&oe.opts)
+	}
+
+	status, hasStatus := "", false
+
+	if oe.opts.forwardStatus {
+		status, hasStatus = stats.LookupStatus(err)
+	}
+
+	switch {
+	case oe.opts.forwardTimeout && hasStatus:
+		return timeoutStatusOpaqueError{timeoutOpaqueError{oe}, status}
+	case oe.opts.forwardTimeout:
+		return timeoutOpaqueError{oe}
+	case hasStatus:
+		return statusOpaqueError{oe, status}
+	}
+
+	return oe
+}
+
+// OpaqueExcept wraps an error to make it opaque like Opaque, but keeps the
+// sentinel errors of allowed matchable by errors.Is.
+func OpaqueExcept(err error, allowed ...error) error {
+	return OpaqueWith(err, Except(allowed...))
+}
+
+// OpaqueExceptTypes wraps an error to make it opaque like Opaque, but keeps
+// the errors of the types of allowed matchable by errors.Is and errors.As,
+// see ExceptTypes.
+func OpaqueExceptTypes(err error, allowed ...error) error {
+	return OpaqueWith(err, ExceptTypes(allowed...))
+}
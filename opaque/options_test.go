@@ -0,0 +1,73 @@
+package opaque_test
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/opaque"
+	"github.com/upfluence/errors/stats"
+)
+
+func TestOpaqueExcept(t *testing.T) {
+	err := opaque.OpaqueExcept(errors.Wrap(context.Canceled, "foo"), context.Canceled, io.EOF)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, io.EOF)
+	assert.Equal(t, err, errors.Cause(err))
+
+	err = errors.OpaqueExcept(errors.Wrap(io.ErrUnexpectedEOF, "foo"), context.Canceled)
+
+	assert.NotErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.NotErrorIs(t, opaque.Opaque(context.Canceled), context.Canceled)
+}
+
+func TestOpaqueExceptTypes(t *testing.T) {
+	_, oserr := os.Open("/does/not/exist")
+
+	err := opaque.OpaqueExceptTypes(errors.Wrap(oserr, "foo"), (*fs.PathError)(nil))
+
+	var perr *fs.PathError
+
+	assert.ErrorAs(t, err, &perr)
+	assert.Equal(t, "/does/not/exist", perr.Path)
+	assert.NotErrorIs(t, err, fs.ErrNotExist)
+
+	var lerr *os.LinkError
+
+	assert.False(t, errors.As(err, &lerr))
+	assert.False(t, errors.As(opaque.Opaque(oserr), &perr))
+}
+
+func TestOpaqueWith(t *testing.T) {
+	err := errors.WithStatus(context.DeadlineExceeded, "fetch_timeout")
+
+	assert.Equal(t, "context deadline exceeded", stats.GetStatus(opaque.Opaque(err)))
+	assert.False(t, errors.IsTimeout(opaque.Opaque(err)))
+
+	oerr := errors.OpaqueWith(err, opaque.ForwardStatus, opaque.ForwardTimeout)
+
+	assert.Equal(t, "fetch_timeout", stats.GetStatus(oerr))
+	assert.True(t, errors.IsTimeout(oerr))
+	assert.NotErrorIs(t, oerr, context.DeadlineExceeded)
+	assert.Equal(t, "context deadline exceeded", oerr.Error())
+
+	// a cause without status leaves the lookup to the rest of the tree
+	oerr = errors.OpaqueWith(errors.New("foo"), opaque.ForwardStatus, opaque.ForwardTimeout)
+
+	assert.Equal(t, "foo", stats.GetStatus(oerr))
+	assert.Equal(
+		t,
+		"eof",
+		stats.GetStatus(errors.Combine(oerr, errors.WithStatus(io.EOF, "eof"))),
+	)
+	assert.True(
+		t,
+		errors.IsTimeout(errors.Combine(errors.Opaque(io.EOF), context.DeadlineExceeded)),
+	)
+}
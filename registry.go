@@ -0,0 +1,15 @@
+package errors
+
+import "github.com/upfluence/errors/registry"
+
+// Register registers the sentinel err under a stable code and returns err.
+// It panics if the code is already registered and is meant to be called at
+// init time.
+func Register(code string, err error) error { return registry.Register(code, err) }
+
+// CodeOf returns the code of the first registered sentinel found in err's
+// chain, or an empty string if there is none.
+func CodeOf(err error) string { return registry.CodeOf(err) }
+
+// ByCode returns the sentinel registered under code, or nil if there is none.
+func ByCode(code string) error { return registry.ByCode(code) }
@@ -0,0 +1,149 @@
+// Package registry provides a registry of sentinel errors identified by
+// stable codes.
+//
+// A code identifies a sentinel error independently from its message, so it
+// does not change when the message is reworded. It gives reporters, metrics
+// and API layers a single identifier to rely on.
+package registry
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/value"
+)
+
+var (
+	mu sync.RWMutex
+
+	sentinels = map[string]error{}
+
+	// codes indexes the sentinels by dynamic type. A lookup only compares
+	// err to the sentinels of its own type, all of them comparable, so it
+	// never compares or hashes the non comparable values a comparable type
+	// may hold in its interface fields.
+	codes = map[reflect.Type][]entry{}
+)
+
+type entry struct {
+	err  error
+	code string
+}
+
+// Register registers the sentinel err under code and returns err, so it can
+// be used to declare the sentinel itself:
+//
+//	var ErrInsufficientFunds = registry.Register(
+//		"billing.insufficient_funds",
+//		errors.New("insufficient funds"),
+//	)
+//
+// Register panics if code is already registered, if err is already
+// registered under another code or if err is not comparable. It is meant to
+// be called at init time.
+func Register(code string, err error) error {
+	if code == "" || err == nil {
+		panic("registry: code and error must not be empty")
+	}
+
+	if !isComparable(err) {
+		panic(fmt.Sprintf("registry: error of type %T is not comparable", err))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := sentinels[code]; ok {
+		panic(fmt.Sprintf("registry: code %q is already registered", code))
+	}
+
+	if c, ok := lookup(err); ok {
+		panic(fmt.Sprintf("registry: error %q is already registered as %q", err, c))
+	}
+
+	t := reflect.TypeOf(err)
+
+	sentinels[code] = err
+	codes[t] = append(codes[t], entry{err: err, code: code})
+
+	return err
+}
+
+func isComparable(err error) (ok bool) {
+	if !reflect.TypeOf(err).Comparable() {
+		return false
+	}
+
+	// a comparable type may still hold a non comparable value in an
+	// interface field
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	return err == err
+}
+
+// Lookup returns the code err is registered under. Unlike CodeOf, it does
+// not traverse the error chain.
+func Lookup(err error) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return lookup(err)
+}
+
+func lookup(err error) (string, bool) {
+	for _, e := range codes[reflect.TypeOf(err)] {
+		if e.err == err {
+			return e.code, true
+		}
+	}
+
+	return "", false
+}
+
+type codeKey struct{}
+
+func (codeKey) Resolve(err error) (interface{}, bool) {
+	c := CodeOf(err)
+	return c, c != ""
+}
+
+// CodeOf returns the code of the first registered sentinel found by
+// traversing the error tree, see base.Inspect for the precedence rule.
+// Errors hiding their chain, like opaque errors, expose the code of their
+// cause.
+// Returns an empty string if no registered sentinel is found.
+func CodeOf(err error) string {
+	var code string
+
+	base.Inspect(err, func(err error) bool {
+		if c, ok := Lookup(err); ok {
+			code = c
+		} else if v, ok := value.Own(err, codeKey{}); ok {
+			code = v.(string)
+		}
+
+		return code == ""
+	})
+
+	return code
+}
+
+// ByCode returns the sentinel registered under code.
+// Returns nil if no sentinel is registered under code.
+func ByCode(code string) error {
+	mu.RLock()
+	err := sentinels[code]
+	mu.RUnlock()
+
+	return err
+}
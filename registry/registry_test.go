@@ -0,0 +1,92 @@
+package registry_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/multi"
+	"github.com/upfluence/errors/registry"
+)
+
+var errInsufficientFunds = registry.Register(
+	"registry_test.insufficient_funds",
+	errors.New("insufficient funds"),
+)
+
+func TestCodeOf(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "nil"},
+		{name: "unregistered", err: errors.New("insufficient funds")},
+		{
+			name: "sentinel",
+			err:  errInsufficientFunds,
+			want: "registry_test.insufficient_funds",
+		},
+		{
+			name: "wrapped",
+			err:  errors.Wrap(errInsufficientFunds, "charge"),
+			want: "registry_test.insufficient_funds",
+		},
+		{
+			name: "stdlib wrapped",
+			err:  fmt.Errorf("charge: %w", errInsufficientFunds),
+			want: "registry_test.insufficient_funds",
+		},
+		{
+			name: "opaque",
+			err:  errors.Opaque(errors.Wrap(errInsufficientFunds, "charge")),
+			want: "registry_test.insufficient_funds",
+		},
+		{
+			name: "comparable type holding a multi error",
+			err:  wrapError{err: multi.Combine(io.EOF, errInsufficientFunds)},
+			want: "registry_test.insufficient_funds",
+		},
+		{
+			name: "unregistered comparable type holding a multi error",
+			err:  wrapError{err: multi.Combine(io.EOF, io.ErrUnexpectedEOF)},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, registry.CodeOf(tt.err))
+		})
+	}
+}
+
+func TestByCode(t *testing.T) {
+	assert.Equal(t, errInsufficientFunds, registry.ByCode("registry_test.insufficient_funds"))
+	assert.Nil(t, registry.ByCode("registry_test.unknown"))
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	assert.Panics(t, func() {
+		registry.Register("registry_test.insufficient_funds", errors.New("other"))
+	})
+
+	assert.Panics(t, func() {
+		registry.Register("registry_test.other", errInsufficientFunds)
+	})
+
+	assert.Panics(t, func() {
+		registry.Register("registry_test.not_comparable", notComparableError{})
+	})
+}
+
+type wrapError struct{ err error }
+
+func (we wrapError) Error() string { return we.err.Error() }
+func (we wrapError) Unwrap() error { return we.err }
+
+type notComparableError struct {
+	s []string
+}
+
+func (notComparableError) Error() string { return "not comparable" }
@@ -8,6 +8,7 @@ package inhibit
 import (
 	"sync"
 
+	"github.com/upfluence/errors/assertion"
 	"github.com/upfluence/errors/reporter"
 )
 
@@ -47,7 +48,13 @@ func (r *Reporter) AddErrorInhibitors(eis ...ErrorInhibitor) {
 func (r *Reporter) Close() error { return r.r.Close() }
 
 // Report reports an error if it is not inhibited by any error inhibitors.
+// Assertion failures are never inhibited.
 func (r *Reporter) Report(err error, opts reporter.ReportOptions) {
+	if assertion.Is(err) {
+		r.r.Report(err, opts)
+		return
+	}
+
 	r.mu.RLock()
 
 	for _, ei := range r.eis {
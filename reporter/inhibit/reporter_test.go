@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/upfluence/errors/assertion"
 	"github.com/upfluence/errors/reporter"
 )
 
@@ -19,7 +20,10 @@ func (mr *mockReporter) Report(error, reporter.ReportOptions) {
 }
 
 func TestReporter(t *testing.T) {
-	var err1 = errors.New("foo")
+	var (
+		err1 = errors.New("foo")
+		err2 = assertion.Failed(0, "buz")
+	)
 
 	for _, tt := range []struct {
 		err    error
@@ -27,6 +31,7 @@ func TestReporter(t *testing.T) {
 	}{
 		{err: err1},
 		{err: errors.New("bar"), called: true},
+		{err: err2, called: true},
 	} {
 		var (
 			mr mockReporter
@@ -35,7 +40,7 @@ func TestReporter(t *testing.T) {
 		)
 
 		r.AddErrorInhibitors(
-			ErrorInhibitorFunc(func(err error) bool { return err == err1 }),
+			ErrorInhibitorFunc(func(err error) bool { return err == err1 || err == err2 }),
 		)
 
 		r.Report(tt.err, reporter.ReportOptions{})
@@ -6,8 +6,11 @@
 package reporter
 
 import (
+	"context"
 	"io"
 
+	"github.com/upfluence/errors/op"
+	"github.com/upfluence/errors/tags"
 	"github.com/upfluence/log/record"
 )
 
@@ -15,6 +18,10 @@ import (
 const (
 	TransactionKey = "transaction"
 	DomainKey      = "domain"
+	ErrorCodeKey   = "error.code"
+	RetryableKey   = "retryable"
+	FingerprintKey = "fingerprint"
+	OpKey          = op.Key
 
 	UserEmailKey = "user.email"
 	UserIDKey    = "user.id"
@@ -36,8 +43,91 @@ const (
 	ThriftRequestServiceKey = "thrift.request.service"
 	ThriftRequestCallerKey  = "thrift.request.caller"
 	ThriftRequestBodyKey    = "thrift.request.body"
+
+	TraceIDKey = "trace.id"
+	SpanIDKey  = "span.id"
+)
+
+// Typed keys of the common tags, see tags.Key. The user email and the request
+// bodies are sensitive, see tags.Sensitive.
+var (
+	TransactionTag = tags.NewKey[string](TransactionKey)
+	DomainTag      = tags.NewKey[string](DomainKey)
+	ErrorCodeTag   = tags.NewKey[string](ErrorCodeKey)
+	RetryableTag   = tags.NewKey[bool](RetryableKey)
+	FingerprintTag = tags.NewKey[string](FingerprintKey)
+	OpTag          = tags.NewKey[string](OpKey)
+
+	UserEmailTag = tags.NewSensitiveKey[string](UserEmailKey)
+	UserIDTag    = tags.NewKey[int64](UserIDKey)
+
+	RemoteIPTag   = tags.NewKey[string](RemoteIP)
+	RemotePortTag = tags.NewKey[int](RemotePort)
+
+	HTTPRequestPathTag   = tags.NewKey[string](HTTPRequestPathKey)
+	HTTPRequestHostTag   = tags.NewKey[string](HTTPRequestHostKey)
+	HTTPRequestProtoTag  = tags.NewKey[string](HTTPRequestProtoKey)
+	HTTPRequestPortTag   = tags.NewKey[int](HTTPRequestPortKey)
+	HTTPRequestMethodTag = tags.NewKey[string](HTTPRequestMethodKey)
+	HTTPRequestBodyTag   = tags.NewSensitiveKey[string](HTTPRequestBodyKey)
+
+	ThriftRequestMethodTag  = tags.NewKey[string](ThriftRequestMethodKey)
+	ThriftRequestServiceTag = tags.NewKey[string](ThriftRequestServiceKey)
+	ThriftRequestCallerTag  = tags.NewKey[string](ThriftRequestCallerKey)
+	ThriftRequestBodyTag    = tags.NewSensitiveKey[interface{}](ThriftRequestBodyKey)
+
+	TraceIDTag = tags.NewKey[string](TraceIDKey)
+	SpanIDTag  = tags.NewKey[string](SpanIDKey)
 )
 
+// HTTPRequestHeaderTag returns the typed key of the request header name.
+func HTTPRequestHeaderTag(name string) tags.Key[string] {
+	return tags.NewKey[string](HTTPRequestHeaderKeyPrefix + name)
+}
+
+// HTTPRequestQueryValueTag returns the typed key of the query value name.
+func HTTPRequestQueryValueTag(name string) tags.Key[string] {
+	return tags.NewKey[string](HTTPRequestQueryValuesKeyPrefix + name)
+}
+
+// TraceContextExtractor returns a tags.ContextExtractor capturing the trace
+// and span identifiers returned by fn under TraceIDKey and SpanIDKey. Empty
+// identifiers are ignored. It allows wiring a tracing library, such as
+// OpenTelemetry, without depending on it:
+//
+//	tags.RegisterContextExtractor(
+//		reporter.TraceContextExtractor(func(ctx context.Context) (string, string) {
+//			sc := trace.SpanContextFromContext(ctx)
+//
+//			if !sc.IsValid() {
+//				return "", ""
+//			}
+//
+//			return sc.TraceID().String(), sc.SpanID().String()
+//		}),
+//	)
+func TraceContextExtractor(fn func(context.Context) (string, string)) tags.ContextExtractor {
+	return func(ctx context.Context) map[string]interface{} {
+		traceID, spanID := fn(ctx)
+
+		var ts map[string]interface{}
+
+		for k, v := range map[string]string{TraceIDKey: traceID, SpanIDKey: spanID} {
+			if v == "" {
+				continue
+			}
+
+			if ts == nil {
+				ts = make(map[string]interface{}, 2)
+			}
+
+			ts[k] = v
+		}
+
+		return ts
+	}
+}
+
 // NopReporter is a Reporter implementation that does nothing.
 var NopReporter Reporter = nopReporter{}
 
@@ -33,7 +33,16 @@ func defaultOptions() Options {
 			SendDefaultPII: true,
 		},
 		TagWhitelist: toStringMap(
-			[]string{reporter.RemoteIP, reporter.RemotePort, reporter.DomainKey},
+			[]string{
+				reporter.RemoteIP,
+				reporter.RemotePort,
+				reporter.DomainKey,
+				reporter.ErrorCodeKey,
+				reporter.RetryableKey,
+				reporter.FingerprintKey,
+				reporter.TraceIDKey,
+				reporter.SpanIDKey,
+			},
 		),
 		Timeout: time.Minute,
 		TagBlacklist: []func(string) bool{
@@ -95,10 +104,12 @@ func ErrorIsOfTypeLevel[T error](level sentry.Level) ErrorLevelMapper {
 }
 
 // ErrorCauseTextContainsLevel creates an ErrorLevelMapper of the passed level that checks
-// if reported errors' cause's Error() text contains the passed string
+// if reported errors' cause's Error() text contains the passed string. The
+// cause is the root cause of the first branch of the multi errors, see
+// base.Root.
 func ErrorCauseTextContainsLevel(errorText string, level sentry.Level) ErrorLevelMapper {
 	return func(err error) sentry.Level {
-		rootCause := base.UnwrapAll(err).Error()
+		rootCause := base.Root(err).Error()
 
 		if strings.Contains(rootCause, errorText) {
 			return level
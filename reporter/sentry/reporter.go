@@ -17,9 +17,16 @@ import (
 
 	"github.com/getsentry/sentry-go"
 
+	"github.com/upfluence/errors/assertion"
 	"github.com/upfluence/errors/base"
 	"github.com/upfluence/errors/domain"
+	"github.com/upfluence/errors/fingerprint"
+	"github.com/upfluence/errors/hint"
+	"github.com/upfluence/errors/message"
+	"github.com/upfluence/errors/registry"
 	"github.com/upfluence/errors/reporter"
+	"github.com/upfluence/errors/retry"
+	"github.com/upfluence/errors/safe"
 	"github.com/upfluence/errors/stacktrace"
 	"github.com/upfluence/errors/tags"
 	"github.com/upfluence/log/record"
@@ -34,6 +41,7 @@ type Reporter struct {
 	levelMappers []ErrorLevelMapper
 
 	timeout time.Duration
+	sendPII bool
 }
 
 // NewReporter creates a new Sentry reporter with the given options.
@@ -60,6 +68,7 @@ func NewReporter(os ...Option) (*Reporter, error) {
 		},
 		tagBlacklist: opts.TagBlacklist,
 		timeout:      opts.Timeout,
+		sendPII:      opts.SentryOptions.SendDefaultPII,
 		levelMappers: opts.ErrorLevelMappers,
 	}, nil
 }
@@ -124,11 +133,12 @@ func (r *Reporter) buildEvent(err error, opts reporter.ReportOptions) *sentry.Ev
 		return nil
 	}
 
-	errorTags := tags.GetTags(err)
+	// report the internal error hidden behind a sanitized one
+	err = safe.Original(err)
 
-	if errorTags == nil && len(opts.Tags) > 0 {
-		errorTags = make(map[string]interface{}, len(opts.Tags))
-	}
+	// the fingerprint is set by GetTags, it is searchable as a tag, not
+	// only used for grouping
+	errorTags := tags.GetTags(err)
 
 	for k, v := range opts.Tags {
 		if _, ok := errorTags[k]; !ok {
@@ -136,16 +146,52 @@ func (r *Reporter) buildEvent(err error, opts reporter.ReportOptions) *sentry.Ev
 		}
 	}
 
+	if code := registry.CodeOf(err); code != "" {
+		errorTags[reporter.ErrorCodeKey] = code
+	}
+
+	if _, ok := errorTags[reporter.RetryableKey]; !ok {
+		if c := retry.Classify(err); c != retry.Unclassified {
+			errorTags[reporter.RetryableKey] = c == retry.Retryable
+		}
+	}
+
+	for k, v := range errorTags {
+		// the values of the report options and of the log records are not
+		// marked, their key tells whether they are sensitive
+		if !tags.IsSensitive(k, v) {
+			continue
+		}
+
+		// sensitive values are personal data as far as Sentry is concerned
+		if r.sendPII {
+			errorTags[k] = tags.Reveal(v)
+		} else {
+			delete(errorTags, k)
+		}
+	}
+
 	evt := sentry.NewEvent()
 
 	evt.Level = r.computeLevel(err, opts)
 	evt.Timestamp = time.Now()
 	evt.Message = err.Error()
+	evt.Fingerprint = []string{fingerprint.Of(err)}
+
+	// the unformatted template is the message of the event, the exception
+	// keeps the formatted one
+	if t := message.GetTemplate(err); len(t.Args) > 0 {
+		evt.Message = t.Format
+		evt.Extra["params"] = buildParams(t)
+	}
+
 	evt.Transaction = transactionName(errorTags)
 	evt.User = buildUser(errorTags)
 	evt.Request = buildRequest(errorTags)
 
-	cause := base.UnwrapAll(err)
+	// the exception describes the root cause the stack trace and the other
+	// call path helpers follow, a multi error is not one
+	cause := base.Root(err)
 
 	evt.Exception = []sentry.Exception{
 		{
@@ -164,6 +210,14 @@ func (r *Reporter) buildEvent(err error, opts reporter.ReportOptions) *sentry.Ev
 		r.appendTag("error_types", ts, evt)
 	}
 
+	if hs := hint.GetHints(err); len(hs) > 0 {
+		evt.Extra["hints"] = hs
+	}
+
+	if ds := hint.GetDetails(err); len(ds) > 0 {
+		evt.Extra["details"] = ds
+	}
+
 	return evt
 }
 
@@ -177,6 +231,11 @@ var reporterToSentryLevel = map[record.Level]sentry.Level{
 }
 
 func (r *Reporter) computeLevel(err error, opts reporter.ReportOptions) sentry.Level {
+	// a broken invariant is always fatal, whatever the caller thinks of it
+	if assertion.Is(err) {
+		return sentry.LevelFatal
+	}
+
 	// we suppose that the programmer knows best: if an error is logged as warning,
 	// it is a warning, in spite of the level mappers.
 	// as Error is the default level, it is ignored for this process
@@ -276,9 +335,25 @@ func transactionName(tags map[string]interface{}) string {
 		)
 	}
 
+	// the op path names the errors raised out of any request, like the
+	// ones of the background jobs
+	if v, ok := tags[reporter.OpKey]; ok {
+		return stringifyTag(v)
+	}
+
 	return ""
 }
 
+func buildParams(t message.Template) []string {
+	params := make([]string, len(t.Args))
+
+	for i, arg := range t.Args {
+		params[i] = stringifyTag(arg)
+	}
+
+	return params
+}
+
 func buildUser(tags map[string]interface{}) sentry.User {
 	return sentry.User{
 		Email: stringifyTag(tags[reporter.UserEmailKey]),
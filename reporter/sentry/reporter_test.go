@@ -16,6 +16,8 @@ import (
 	"github.com/upfluence/pkg/pointers"
 )
 
+var errRegistered = errors.Register("sentry.registered", errors.New("registered"))
+
 type mockError struct{}
 
 func (*mockError) Error() string { return "mock" }
@@ -63,6 +65,37 @@ func TestBuildEvent(t *testing.T) {
 				assert.Equal(t, "transaction#27", evt.Transaction)
 			},
 		},
+		{
+			name: "error op path",
+			err:  errors.WithOp(errors.WithOp(errors.New("basic error"), "db.Query"), "user.Fetch"),
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				assert.Equal(t, "user.Fetch > db.Query", evt.Transaction)
+				assert.Equal(t, "user.Fetch > db.Query", evt.Extra[reporter.OpKey])
+			},
+		},
+		{
+			name: "error op path and transaction tag",
+			err: errors.WithTags(
+				errors.WithOp(errors.New("basic error"), "user.Fetch"),
+				map[string]interface{}{reporter.TransactionKey: "transaction#27"},
+			),
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				assert.Equal(t, "transaction#27", evt.Transaction)
+			},
+		},
+		{
+			name: "error op path with http opts",
+			err:  errors.WithOp(errors.New("basic error"), "user.Fetch"),
+			ropts: reporter.ReportOptions{
+				Tags: map[string]interface{}{
+					reporter.HTTPRequestMethodKey: "GET",
+					reporter.HTTPRequestPathKey:   "/users",
+				},
+			},
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				assert.Equal(t, "GET /users", evt.Transaction)
+			},
+		},
 		{
 			name: "simple error with thrift opts",
 			err:  errors.New("basic error"),
@@ -170,6 +203,152 @@ func TestBuildEvent(t *testing.T) {
 				assert.Equal(t, evt.Tags, map[string]string{"foo": "bar", "domain": "github.com/upfluence/errors/reporter/sentry"})
 			},
 		},
+		{
+			name: "typed user tags",
+			err: errors.WithTag(
+				errors.WithTag(errors.New("basic error"), reporter.UserIDTag, 42),
+				reporter.UserEmailTag,
+				"john@example.com",
+			),
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				assert.Equal(
+					t,
+					sentry.User{ID: "42", Email: "john@example.com"},
+					evt.User,
+				)
+			},
+		},
+		{
+			name: "sensitive tags",
+			err: errors.WithTags(
+				errors.WithTag(errors.New("basic error"), reporter.UserEmailTag, "john@example.com"),
+				map[string]interface{}{"token": errors.Sensitive("s3cr3t")},
+			),
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				assert.Equal(t, sentry.User{Email: "john@example.com"}, evt.User)
+				assert.Equal(t, "s3cr3t", evt.Extra["token"])
+			},
+		},
+		{
+			name: "sensitive tags without PII",
+			err: errors.WithTags(
+				errors.WithTag(errors.New("basic error"), reporter.UserEmailTag, "john@example.com"),
+				map[string]interface{}{"token": errors.Sensitive("s3cr3t")},
+			),
+			modifiers: []func(*Reporter){
+				func(r *Reporter) { r.sendPII = false },
+			},
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				assert.Equal(t, sentry.User{}, evt.User)
+				assert.NotContains(t, evt.Extra, "token")
+			},
+		},
+		{
+			name: "sensitive report options without PII",
+			err:  errors.New("basic error"),
+			ropts: reporter.ReportOptions{
+				Tags: map[string]interface{}{
+					reporter.UserEmailKey:       "john@example.com",
+					reporter.HTTPRequestBodyKey: "{}",
+				},
+			},
+			modifiers: []func(*Reporter){
+				func(r *Reporter) { r.sendPII = false },
+			},
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				assert.Equal(t, sentry.User{}, evt.User)
+				assert.NotContains(t, evt.Extra, reporter.HTTPRequestBodyKey)
+			},
+		},
+		{
+			name: "fingerprint",
+			err:  errors.Wrapf(errors.New("basic error"), "user %d", 42),
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				assert.Len(t, evt.Fingerprint, 1)
+				assert.Len(t, evt.Fingerprint[0], 32)
+			},
+		},
+		{
+			name: "message template",
+			err:  errors.Wrapf(errors.Wrap(io.EOF, "100%"), "user %d not found", 42),
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				assert.Equal(t, "user %d not found: 100%%: EOF", evt.Message)
+				assert.Equal(t, []string{"42"}, evt.Extra["params"])
+				assert.Equal(t, map[string]map[string]interface{}{}, evt.Contexts)
+			},
+		},
+		{
+			name: "fingerprint override",
+			err:  errors.WithFingerprint(errors.New("basic error"), "foo", "bar"),
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				// the harness checks the fingerprint tag matches
+				assert.Equal(t, []string{"foo:bar"}, evt.Fingerprint)
+			},
+		},
+		{
+			name: "sanitized error",
+			err: errors.Sanitize(
+				errors.WithUserMessage(errors.Wrap(errRegistered, "pool exhausted"), "try again"),
+			),
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				assert.Equal(t, "pool exhausted: registered", evt.Message)
+				assert.Equal(t, "sentry.registered", evt.Tags["error.code"])
+			},
+		},
+		{
+			name: "hints and details",
+			err: errors.WithSecondaryError(
+				errors.WithHint(errors.WithDetail(errors.New("basic error"), "foo"), "bar"),
+				errors.WithHint(errors.New("close error"), "bar"),
+			),
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				assert.Equal(t, []string{"bar"}, evt.Extra["hints"])
+				assert.Equal(t, []string{"foo"}, evt.Extra["details"])
+			},
+		},
+		{
+			name: "assertion failure",
+			err:  errors.AssertionFailed("unexpected state %d", 42),
+			modifiers: []func(*Reporter){
+				func(r *Reporter) {
+					r.levelMappers = []ErrorLevelMapper{
+						func(error) sentry.Level { return sentry.LevelDebug },
+					}
+				},
+			},
+			ropts: reporter.ReportOptions{ReportedLevel: pointers.Ptr(record.Warning)},
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				assert.Equal(t, sentry.LevelFatal, evt.Level)
+			},
+		},
+		{
+			name: "retryable error",
+			err:  errors.Wrap(context.DeadlineExceeded, "wrapped"),
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				assert.Equal(t, "true", evt.Tags[reporter.RetryableKey])
+			},
+		},
+		{
+			name: "permanent error",
+			err:  errors.WithRetryable(errors.New("basic error"), false),
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				assert.Equal(t, "false", evt.Tags[reporter.RetryableKey])
+			},
+		},
+		{
+			name: "registered error",
+			err:  errors.Wrap(errRegistered, "wrapped"),
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				assert.Equal(
+					t,
+					map[string]string{
+						"domain":     "github.com/upfluence/errors/reporter/sentry",
+						"error.code": "sentry.registered",
+					},
+					evt.Tags,
+				)
+			},
+		},
 		{
 			name:      "simple sentinel error with severity func",
 			err:       io.EOF,
@@ -221,6 +400,24 @@ func TestBuildEvent(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "combined text error with severity func",
+			err: errors.Wrap(
+				errors.Combine(errors.New("net/http: TLS handshake timeout"), io.EOF),
+				"fetch",
+			),
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				assert.Equal(t, sentry.LevelWarning, evt.Level)
+			},
+		},
+		{
+			name: "combined error exception",
+			err:  errors.Wrap(errors.Combine(&mockError{}, io.EOF), "fetch"),
+			evtfn: func(t *testing.T, evt *sentry.Event) {
+				assert.Equal(t, "*sentry.mockError", evt.Exception[0].Type)
+				assert.Equal(t, "mock", evt.Exception[0].Value)
+			},
+		},
 		{
 			name: "simple error type",
 			err:  &mockError{},
@@ -314,6 +511,14 @@ func TestBuildEvent(t *testing.T) {
 			}
 
 			evt := r.buildEvent(tt.err, tt.ropts)
+
+			if evt != nil {
+				// every event is tagged with its fingerprint, the cases
+				// only check the rest of the tags
+				assert.Equal(t, evt.Fingerprint, []string{evt.Tags[reporter.FingerprintKey]})
+				delete(evt.Tags, reporter.FingerprintKey)
+			}
+
 			tt.evtfn(t, evt)
 		})
 	}
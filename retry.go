@@ -0,0 +1,27 @@
+package errors
+
+import (
+	"time"
+
+	"github.com/upfluence/errors/retry"
+)
+
+// WithRetryable marks the error as worth retrying or not and adds a stack
+// frame.
+func WithRetryable(err error, retryable bool) error {
+	return WithFrame(retry.WithRetryable(err, retryable), 1)
+}
+
+// WithRetryAfter marks the error as worth retrying after d and adds a stack
+// frame.
+func WithRetryAfter(err error, d time.Duration) error {
+	return WithFrame(retry.WithRetryAfter(err, d), 1)
+}
+
+// IsRetryable reports whether err is worth retrying, either because it was
+// marked so or because it is a timeout, a temporary error or a connection
+// reset, see retry.Classify.
+func IsRetryable(err error) bool { return retry.IsRetryable(err) }
+
+// RetryAfter returns the retry-after hint carried by err, 0 if none.
+func RetryAfter(err error) time.Duration { return retry.RetryAfter(err) }
@@ -0,0 +1,166 @@
+package retry
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/upfluence/errors/multi"
+	"github.com/upfluence/errors/tags"
+)
+
+const (
+	// AttemptsKey is the tag key of the number of attempts made by Do.
+	AttemptsKey = "retry.attempts"
+
+	// ElapsedKey is the tag key of the time spent by Do, a time.Duration.
+	ElapsedKey = "retry.elapsed"
+)
+
+// Policy configures Do. The zero value of every field stands for the value
+// of DefaultPolicy.
+type Policy struct {
+	// MaxAttempts is the maximum number of calls, the first one included.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the second attempt, every following
+	// delay is multiplied by Multiplier, up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+
+	// Jitter is the fraction of every delay randomly added or removed, it is
+	// clamped to [0, 1]. A negative value disables the jitter.
+	Jitter float64
+
+	// AttemptTimeout bounds the duration of every attempt, no bound by
+	// default.
+	AttemptTimeout time.Duration
+
+	// ShouldRetry reports whether an attempt failing with err is worth
+	// retrying. It defaults to IsRetryable, which covers the timeouts.
+	ShouldRetry func(err error) bool
+}
+
+// DefaultPolicy is the policy used for the fields left empty.
+var DefaultPolicy = Policy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+	ShouldRetry:    IsRetryable,
+}
+
+func (p Policy) withDefaults() Policy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultPolicy.MaxAttempts
+	}
+
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = DefaultPolicy.InitialBackoff
+	}
+
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = DefaultPolicy.MaxBackoff
+	}
+
+	if p.Multiplier <= 0 {
+		p.Multiplier = DefaultPolicy.Multiplier
+	}
+
+	switch {
+	case p.Jitter == 0:
+		p.Jitter = DefaultPolicy.Jitter
+	case p.Jitter < 0:
+		p.Jitter = 0
+	case p.Jitter > 1:
+		p.Jitter = 1
+	}
+
+	if p.ShouldRetry == nil {
+		p.ShouldRetry = DefaultPolicy.ShouldRetry
+	}
+
+	return p
+}
+
+func (p Policy) backoff(attempt int) time.Duration {
+	d := float64(p.InitialBackoff)
+
+	for i := 1; i < attempt && d < float64(p.MaxBackoff); i++ {
+		d *= p.Multiplier
+	}
+
+	d = min(d, float64(p.MaxBackoff))
+
+	return time.Duration(d * (1 + p.Jitter*(2*rand.Float64()-1)))
+}
+
+// Do calls fn until it succeeds, returns an error not worth retrying, or the
+// attempts of the policy run out. Between two attempts it waits for the
+// retry-after hint of the error if any, see RetryAfter, or for an
+// exponential backoff otherwise.
+//
+// On failure the errors of every attempt are combined with multi.Wrap and
+// tagged with the number of attempts and the elapsed time. If ctx is done
+// while waiting, its error is combined as well.
+func Do(ctx context.Context, fn func(context.Context) error, p Policy) error {
+	var (
+		errs []error
+
+		start = time.Now()
+	)
+
+	p = p.withDefaults()
+
+	for attempt := 1; ; attempt++ {
+		err := call(ctx, fn, p.AttemptTimeout)
+
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, err)
+
+		if attempt >= p.MaxAttempts || !p.ShouldRetry(err) {
+			return giveUp(errs, attempt, start)
+		}
+
+		d := RetryAfter(err)
+
+		if d <= 0 {
+			d = p.backoff(attempt)
+		}
+
+		t := time.NewTimer(d)
+
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return giveUp(append(errs, ctx.Err()), attempt, start)
+		case <-t.C:
+		}
+	}
+}
+
+func call(ctx context.Context, fn func(context.Context) error, timeout time.Duration) error {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return fn(ctx)
+}
+
+func giveUp(errs []error, attempts int, start time.Time) error {
+	return tags.WithTags(
+		multi.Wrap(errs),
+		map[string]interface{}{
+			AttemptsKey: attempts,
+			ElapsedKey:  time.Since(start),
+		},
+	)
+}
@@ -0,0 +1,138 @@
+package retry_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/multi"
+	"github.com/upfluence/errors/retry"
+	"github.com/upfluence/errors/tags"
+)
+
+var fastPolicy = retry.Policy{InitialBackoff: time.Millisecond, MaxAttempts: 4}
+
+func attempts(errs ...error) (func(context.Context) error, *int) {
+	var n int
+
+	return func(context.Context) error {
+		n++
+
+		if n > len(errs) {
+			return nil
+		}
+
+		return errs[n-1]
+	}, &n
+}
+
+func TestDo(t *testing.T) {
+	var errRetryable = errors.WithRetryable(io.EOF, true)
+
+	for _, tt := range []struct {
+		name string
+		errs []error
+
+		wantCalls int
+		wantErrs  int
+	}{
+		{name: "success", wantCalls: 1},
+		{name: "retried", errs: []error{errRetryable, context.DeadlineExceeded}, wantCalls: 3},
+		{name: "permanent", errs: []error{errRetryable, io.EOF}, wantCalls: 2, wantErrs: 2},
+		{
+			name:      "exhausted",
+			errs:      []error{errRetryable, errRetryable, errRetryable, errRetryable},
+			wantCalls: 4,
+			wantErrs:  4,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			fn, n := attempts(tt.errs...)
+
+			err := retry.Do(context.Background(), fn, fastPolicy)
+
+			assert.Equal(t, tt.wantCalls, *n)
+
+			if tt.wantErrs == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Len(t, multi.ExtractErrors(errors.Unwrap(err)), tt.wantErrs)
+
+			ts := tags.GetTags(err)
+
+			assert.Equal(t, tt.wantCalls, ts[retry.AttemptsKey])
+			assert.IsType(t, time.Duration(0), ts[retry.ElapsedKey])
+			assert.ErrorIs(t, err, io.EOF)
+		})
+	}
+}
+
+func TestDoRetryAfter(t *testing.T) {
+	fn, n := attempts(errors.WithRetryAfter(io.EOF, 20*time.Millisecond))
+
+	start := time.Now()
+
+	assert.NoError(t, retry.Do(context.Background(), fn, fastPolicy))
+	assert.Equal(t, 2, *n)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestDoNoJitter(t *testing.T) {
+	fn, n := attempts(errors.WithRetryable(io.EOF, true))
+
+	start := time.Now()
+
+	assert.NoError(
+		t,
+		retry.Do(
+			context.Background(),
+			fn,
+			retry.Policy{InitialBackoff: 20 * time.Millisecond, Jitter: -1},
+		),
+	)
+	assert.Equal(t, 2, *n)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestDoAttemptTimeout(t *testing.T) {
+	var n int
+
+	err := retry.Do(
+		context.Background(),
+		func(ctx context.Context) error {
+			n++
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		retry.Policy{
+			MaxAttempts:    2,
+			InitialBackoff: time.Millisecond,
+			AttemptTimeout: time.Millisecond,
+		},
+	)
+
+	assert.Equal(t, 2, n)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestDoCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err := retry.Do(
+		ctx,
+		func(context.Context) error {
+			cancel()
+			return errors.WithRetryable(io.EOF, true)
+		},
+		retry.Policy{InitialBackoff: time.Hour},
+	)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, io.EOF)
+}
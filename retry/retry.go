@@ -0,0 +1,143 @@
+// Package retry classifies errors as worth retrying or not.
+//
+// The classification is either attached explicitly, with WithRetryable and
+// WithRetryAfter, or inferred from well-known errors: timeouts, temporary
+// errors, context.DeadlineExceeded and connection resets or refusals. Do
+// retries a function based on this classification.
+package retry
+
+import (
+	"context"
+	"syscall"
+	"time"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/value"
+)
+
+const (
+	// RetryableKey is the tag key of the explicit retryability of an error.
+	RetryableKey = "retryable"
+
+	// RetryAfterKey is the tag key of the retry-after hint of an error.
+	RetryAfterKey = "retry.after"
+)
+
+// Class is the retryability of an error.
+type Class uint8
+
+const (
+	// Unclassified errors carry no hint about their retryability.
+	Unclassified Class = iota
+
+	// Retryable errors are worth retrying.
+	Retryable
+
+	// Permanent errors are not worth retrying.
+	Permanent
+)
+
+func (c Class) String() string {
+	switch c {
+	case Retryable:
+		return "retryable"
+	case Permanent:
+		return "permanent"
+	}
+
+	return "unclassified"
+}
+
+type classKey struct{}
+
+func (classKey) Resolve(err error) (interface{}, bool) {
+	c := Classify(err)
+	return c, c != Unclassified
+}
+
+// Classify returns the retryability of err by traversing the error tree. The
+// first error carrying a hint wins, see base.Inspect for the precedence rule.
+// The hints are, in order:
+//
+//   - an explicit class, exposed by RetryClass() Class
+//   - context.Canceled is permanent, context.DeadlineExceeded is retryable
+//   - syscall.ECONNRESET and syscall.ECONNREFUSED are retryable
+//   - errors returning true from Timeout() bool or Temporary() bool, like
+//     net.Error, are retryable
+//
+// Errors hiding their chain, like opaque errors, expose the class of their
+// cause.
+func Classify(err error) Class {
+	var c = Unclassified
+
+	base.Inspect(err, func(err error) bool {
+		c = classify(err)
+		return c == Unclassified
+	})
+
+	return c
+}
+
+func classify(err error) Class {
+	if cerr, ok := err.(interface{ RetryClass() Class }); ok {
+		if c := cerr.RetryClass(); c != Unclassified {
+			return c
+		}
+	}
+
+	if v, ok := value.Own(err, classKey{}); ok {
+		return v.(Class)
+	}
+
+	switch err {
+	case context.Canceled:
+		return Permanent
+	case context.DeadlineExceeded:
+		return Retryable
+	}
+
+	if errno, ok := err.(syscall.Errno); ok {
+		if errno == syscall.ECONNRESET || errno == syscall.ECONNREFUSED {
+			return Retryable
+		}
+	}
+
+	if terr, ok := err.(interface{ Timeout() bool }); ok && terr.Timeout() {
+		return Retryable
+	}
+
+	if terr, ok := err.(interface{ Temporary() bool }); ok && terr.Temporary() {
+		return Retryable
+	}
+
+	return Unclassified
+}
+
+// IsRetryable reports whether err is worth retrying, see Classify.
+func IsRetryable(err error) bool { return Classify(err) == Retryable }
+
+type retryAfterKey struct{}
+
+func (retryAfterKey) Resolve(err error) (interface{}, bool) {
+	d := RetryAfter(err)
+	return d, d > 0
+}
+
+// RetryAfter returns the outermost retry-after hint of the error tree.
+// Errors hiding their chain, like opaque errors, expose the hint of their
+// cause. Returns 0 if no hint is found.
+func RetryAfter(err error) time.Duration {
+	var d time.Duration
+
+	base.Inspect(err, func(err error) bool {
+		if rerr, ok := err.(interface{ RetryAfter() time.Duration }); ok {
+			d = rerr.RetryAfter()
+		} else if v, ok := value.Own(err, retryAfterKey{}); ok {
+			d = v.(time.Duration)
+		}
+
+		return d <= 0
+	})
+
+	return d
+}
@@ -0,0 +1,145 @@
+package retry_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/errtest"
+	"github.com/upfluence/errors/retry"
+	"github.com/upfluence/errors/tags"
+)
+
+func TestWithRetryable(t *testing.T) {
+	errtest.TestErrorWrapper(
+		t,
+		func(err error) error { return errors.WithRetryable(err, true) },
+		errtest.ErrorWrapperOptions{N: 2},
+	)
+}
+
+func TestWithRetryAfter(t *testing.T) {
+	errtest.TestErrorWrapper(
+		t,
+		func(err error) error { return errors.WithRetryAfter(err, time.Second) },
+		errtest.ErrorWrapperOptions{N: 2},
+	)
+}
+
+type temporaryError struct{ temporary bool }
+
+func (temporaryError) Error() string     { return "temporary" }
+func (e temporaryError) Temporary() bool { return e.temporary }
+
+func TestClassify(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want retry.Class
+	}{
+		{name: "nil", want: retry.Unclassified},
+		{name: "unknown", err: errors.New("foo"), want: retry.Unclassified},
+		{name: "stdlib", err: io.EOF, want: retry.Unclassified},
+		{name: "retryable", err: errors.WithRetryable(io.EOF, true), want: retry.Retryable},
+		{name: "permanent", err: errors.WithRetryable(io.EOF, false), want: retry.Permanent},
+		{name: "retry after", err: errors.WithRetryAfter(io.EOF, time.Second), want: retry.Retryable},
+		{
+			name: "outermost",
+			err:  errors.WithRetryable(errors.WithRetryable(io.EOF, true), false),
+			want: retry.Permanent,
+		},
+		{
+			name: "permanent timeout",
+			err:  errors.WithRetryable(context.DeadlineExceeded, false),
+			want: retry.Permanent,
+		},
+		{name: "canceled", err: errors.Wrap(context.Canceled, "foo"), want: retry.Permanent},
+		{
+			name: "deadline exceeded",
+			err:  errors.Wrap(context.DeadlineExceeded, "foo"),
+			want: retry.Retryable,
+		},
+		{
+			name: "connection reset",
+			err:  &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)},
+			want: retry.Retryable,
+		},
+		{
+			name: "connection refused",
+			err:  fmt.Errorf("dial: %w", syscall.ECONNREFUSED),
+			want: retry.Retryable,
+		},
+		{name: "temporary", err: errors.Wrap(temporaryError{true}, "foo"), want: retry.Retryable},
+		{name: "not temporary", err: temporaryError{}, want: retry.Unclassified},
+		{
+			name: "net timeout",
+			err:  &net.DNSError{Err: "timeout", IsTimeout: true},
+			want: retry.Retryable,
+		},
+		{
+			name: "multi",
+			err:  errors.Combine(errors.New("foo"), errors.WithRetryable(io.EOF, true)),
+			want: retry.Retryable,
+		},
+		{
+			name: "opaque",
+			err:  errors.Opaque(errors.WithRetryable(io.EOF, false)),
+			want: retry.Permanent,
+		},
+		{
+			name: "opaque timeout",
+			err:  errors.Opaque(context.DeadlineExceeded),
+			want: retry.Retryable,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, retry.Classify(tt.err))
+			assert.Equal(t, tt.want == retry.Retryable, errors.IsRetryable(tt.err))
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), errors.RetryAfter(nil))
+	assert.Equal(t, time.Duration(0), errors.RetryAfter(errors.WithRetryable(io.EOF, true)))
+	assert.Equal(
+		t,
+		time.Second,
+		errors.RetryAfter(errors.Opaque(errors.WithRetryAfter(io.EOF, time.Second))),
+	)
+	assert.Equal(
+		t,
+		time.Minute,
+		errors.RetryAfter(
+			errors.Wrap(
+				errors.WithRetryAfter(errors.WithRetryAfter(io.EOF, time.Second), time.Minute),
+				"foo",
+			),
+		),
+	)
+}
+
+func TestTags(t *testing.T) {
+	err := errors.WithRetryAfter(errors.New("foo"), time.Second)
+
+	assert.Equal(
+		t,
+		map[string]interface{}{
+			"domain":            "github.com/upfluence/errors/retry_test",
+			"fingerprint":       errors.Fingerprint(err),
+			retry.RetryableKey:  true,
+			retry.RetryAfterKey: "1s",
+		},
+		tags.GetTags(err),
+	)
+
+	assert.Contains(t, fmt.Sprintf("%+v", errors.WithRetryable(io.EOF, false)), "retryable: false")
+}
@@ -0,0 +1,83 @@
+package retry
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/internal/logvalue"
+	"github.com/upfluence/errors/stacktrace"
+)
+
+type withRetryable struct {
+	cause     error
+	retryable bool
+}
+
+func (wr *withRetryable) Error() string { return wr.cause.Error() }
+func (wr *withRetryable) Unwrap() error { return wr.cause }
+func (wr *withRetryable) Cause() error  { return wr.cause }
+
+func (wr *withRetryable) Format(s fmt.State, verb rune) { base.FormatError(wr, s, verb) }
+func (wr *withRetryable) LogValue() slog.Value          { return logvalue.Of(wr, stacktrace.Origin(wr)) }
+
+func (wr *withRetryable) FormatError(p base.Printer) error {
+	p.Printf("retryable: %t", wr.retryable)
+	return wr.cause
+}
+
+func (wr *withRetryable) RetryClass() Class {
+	if wr.retryable {
+		return Retryable
+	}
+
+	return Permanent
+}
+
+func (wr *withRetryable) Tags() map[string]interface{} {
+	return map[string]interface{}{RetryableKey: wr.retryable}
+}
+
+// WithRetryable marks the error as worth retrying or not.
+// Returns nil if err is nil.
+func WithRetryable(err error, retryable bool) error {
+	if err == nil {
+		return nil
+	}
+
+	return &withRetryable{cause: err, retryable: retryable}
+}
+
+type withRetryAfter struct {
+	cause error
+	after time.Duration
+}
+
+func (wr *withRetryAfter) Error() string             { return wr.cause.Error() }
+func (wr *withRetryAfter) Unwrap() error             { return wr.cause }
+func (wr *withRetryAfter) Cause() error              { return wr.cause }
+func (wr *withRetryAfter) RetryClass() Class         { return Retryable }
+func (wr *withRetryAfter) RetryAfter() time.Duration { return wr.after }
+
+func (wr *withRetryAfter) Format(s fmt.State, verb rune) { base.FormatError(wr, s, verb) }
+func (wr *withRetryAfter) LogValue() slog.Value          { return logvalue.Of(wr, stacktrace.Origin(wr)) }
+
+func (wr *withRetryAfter) FormatError(p base.Printer) error {
+	p.Printf("retry after: %s", wr.after)
+	return wr.cause
+}
+
+func (wr *withRetryAfter) Tags() map[string]interface{} {
+	return map[string]interface{}{RetryableKey: true, RetryAfterKey: wr.after.String()}
+}
+
+// WithRetryAfter marks the error as worth retrying after d.
+// Returns nil if err is nil.
+func WithRetryAfter(err error, d time.Duration) error {
+	if err == nil {
+		return nil
+	}
+
+	return &withRetryAfter{cause: err, after: d}
+}
@@ -0,0 +1,17 @@
+package errors
+
+import "github.com/upfluence/errors/safe"
+
+// WithUserMessage attaches a message safe to expose to the users and adds a
+// stack frame. It does not change Error().
+func WithUserMessage(err error, msg string) error {
+	return WithFrame(safe.WithUserMessage(err, msg), 1)
+}
+
+// UserMessage returns the outermost user message of err, seeing through opaque
+// errors. Returns fallback if no user message is found.
+func UserMessage(err error, fallback string) string { return safe.UserMessage(err, fallback) }
+
+// Sanitize returns an error exposing only the user message of err, see
+// safe.Sanitize.
+func Sanitize(err error) error { return safe.Sanitize(err) }
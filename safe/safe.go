@@ -0,0 +1,120 @@
+// Package safe provides user-facing messages, safe to expose outside of the
+// service, alongside the internal error messages.
+//
+// Error() is meant for operators and often holds internal details. A safe
+// message is attached with WithUserMessage and read back with UserMessage,
+// Sanitize builds an error exposing only the safe message.
+package safe
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/internal/logvalue"
+	"github.com/upfluence/errors/stacktrace"
+	"github.com/upfluence/errors/value"
+)
+
+// DefaultMessage is the message of the sanitized errors carrying no user
+// message.
+const DefaultMessage = "internal error"
+
+type withUserMessage struct {
+	cause error
+	msg   string
+}
+
+func (wu *withUserMessage) Error() string       { return wu.cause.Error() }
+func (wu *withUserMessage) Unwrap() error       { return wu.cause }
+func (wu *withUserMessage) Cause() error        { return wu.cause }
+func (wu *withUserMessage) UserMessage() string { return wu.msg }
+
+func (wu *withUserMessage) Format(s fmt.State, verb rune) { base.FormatError(wu, s, verb) }
+func (wu *withUserMessage) LogValue() slog.Value          { return logvalue.Of(wu, stacktrace.Origin(wu)) }
+
+func (wu *withUserMessage) FormatError(p base.Printer) error {
+	p.Printf("user message: %s", wu.msg)
+	return wu.cause
+}
+
+// WithUserMessage attaches a message safe to expose to the users. It does not
+// change Error().
+// Returns err if err is nil or if msg is empty.
+func WithUserMessage(err error, msg string) error {
+	if err == nil || msg == "" {
+		return err
+	}
+
+	return &withUserMessage{cause: err, msg: msg}
+}
+
+type userMessageKey struct{}
+
+func (userMessageKey) Resolve(err error) (interface{}, bool) {
+	msg := UserMessage(err, "")
+	return msg, msg != ""
+}
+
+// UserMessage returns the outermost user message of the error tree, see
+// base.Inspect for the precedence rule. Errors hiding their chain, like
+// opaque errors, expose the user message of their cause.
+// Returns fallback if no user message is found.
+func UserMessage(err error, fallback string) string {
+	var msg string
+
+	base.Inspect(err, func(err error) bool {
+		if uerr, ok := err.(interface{ UserMessage() string }); ok {
+			msg = uerr.UserMessage()
+		} else if v, ok := value.Own(err, userMessageKey{}); ok {
+			msg = v.(string)
+		}
+
+		return msg == ""
+	})
+
+	if msg == "" {
+		return fallback
+	}
+
+	return msg
+}
+
+type sanitizedError struct {
+	original error
+	msg      string
+}
+
+func (se *sanitizedError) Error() string       { return se.msg }
+func (se *sanitizedError) UserMessage() string { return se.msg }
+func (se *sanitizedError) Original() error     { return se.original }
+
+func (se *sanitizedError) Format(s fmt.State, verb rune) { base.FormatError(se, s, verb) }
+func (se *sanitizedError) LogValue() slog.Value          { return logvalue.Of(se, stacktrace.Origin(se)) }
+
+// FormatError prints nothing, the original error must not leak through the
+// verbose rendering.
+func (se *sanitizedError) FormatError(base.Printer) error { return nil }
+
+// Sanitize returns an error whose message is the user message of err, or
+// DefaultMessage if it has none. The returned error does not expose err
+// through Unwrap, formatting, logging or encoding, so no internal message,
+// tag value or file path can leak. Reporters reach err with Original.
+// Returns nil if err is nil.
+func Sanitize(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &sanitizedError{original: err, msg: UserMessage(err, DefaultMessage)}
+}
+
+// Original returns the error sanitized by Sanitize, err itself if it was not
+// sanitized.
+func Original(err error) error {
+	if serr, ok := err.(interface{ Original() error }); ok {
+		return serr.Original()
+	}
+
+	return err
+}
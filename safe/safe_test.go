@@ -0,0 +1,98 @@
+package safe_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/errjson"
+	"github.com/upfluence/errors/errtest"
+	"github.com/upfluence/errors/safe"
+	"github.com/upfluence/errors/wire"
+)
+
+func TestWithUserMessage(t *testing.T) {
+	errtest.TestErrorWrapper(
+		t,
+		func(err error) error { return errors.WithUserMessage(err, "foo") },
+		errtest.ErrorWrapperOptions{N: 2},
+	)
+}
+
+func TestUserMessage(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "nil", want: "fallback"},
+		{name: "no message", err: errors.New("foo"), want: "fallback"},
+		{name: "message", err: errors.WithUserMessage(io.EOF, "foo"), want: "foo"},
+		{
+			name: "outermost",
+			err:  errors.WithUserMessage(errors.Wrap(errors.WithUserMessage(io.EOF, "foo"), "bar"), "buz"),
+			want: "buz",
+		},
+		{
+			name: "opaque",
+			err:  errors.Wrap(errors.Opaque(errors.WithUserMessage(io.EOF, "foo")), "bar"),
+			want: "foo",
+		},
+		{
+			name: "multi",
+			err:  errors.Combine(io.EOF, errors.WithUserMessage(io.EOF, "foo")),
+			want: "foo",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errors.UserMessage(tt.err, "fallback"))
+		})
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	assert.Nil(t, errors.Sanitize(nil))
+	assert.Equal(t, safe.DefaultMessage, errors.Sanitize(io.EOF).Error())
+
+	orig := errors.WithUserMessage(
+		errors.WithTags(
+			errors.Wrap(errors.New("database connection pool exhausted"), "query"),
+			map[string]interface{}{"api.key": "s3cr3t"},
+		),
+		"try again later",
+	)
+
+	err := errors.Sanitize(orig)
+
+	buf, jerr := json.Marshal(errjson.Encode(err))
+	assert.NoError(t, jerr)
+
+	var b strings.Builder
+	slog.New(slog.NewTextHandler(&b, nil)).Error("failed", "error", err)
+
+	for _, out := range []string{
+		err.Error(),
+		fmt.Sprintf("%v", err),
+		fmt.Sprintf("%+v", err),
+		fmt.Sprintf("%#v", err),
+		string(buf),
+		string(wire.Encode(err)),
+		b.String(),
+	} {
+		assert.Contains(t, out, "try again later")
+
+		for _, leak := range []string{"pool", "query", "api.key", "s3cr3t", "safe_test.go"} {
+			assert.NotContains(t, out, leak)
+		}
+	}
+
+	assert.Equal(t, orig, safe.Original(err))
+	assert.Equal(t, io.EOF, safe.Original(io.EOF))
+	assert.Nil(t, errors.Unwrap(err))
+}
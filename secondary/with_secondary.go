@@ -6,9 +6,14 @@
 package secondary
 
 import (
+	"fmt"
+	"log/slog"
 	"strings"
 
-	"github.com/upfluence/errors/tags"
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/internal/logvalue"
+	"github.com/upfluence/errors/internal/tagset"
+	"github.com/upfluence/errors/stacktrace"
 )
 
 type withSecondary struct {
@@ -27,11 +32,20 @@ func (ws *withSecondary) Error() string {
 	return b.String()
 }
 
-func (ws *withSecondary) Unwrap() error { return ws.cause }
-func (ws *withSecondary) Cause() error  { return ws.cause }
+func (ws *withSecondary) Unwrap() error         { return ws.cause }
+func (ws *withSecondary) Cause() error          { return ws.cause }
+func (ws *withSecondary) SecondaryError() error { return ws.second }
+
+func (ws *withSecondary) Format(s fmt.State, verb rune) { base.FormatError(ws, s, verb) }
+func (ws *withSecondary) LogValue() slog.Value          { return logvalue.Of(ws, stacktrace.Origin(ws)) }
+
+func (ws *withSecondary) FormatError(p base.Printer) error {
+	p.Branch("secondary", ws.second)
+	return ws.cause
+}
 
 func (ws *withSecondary) Tags() map[string]interface{} {
-	return tags.GetTags(ws.second)
+	return tagset.Collect(ws.second)
 }
 
 func (ws *withSecondary) Errors() []error {
@@ -2,6 +2,14 @@ package errors
 
 import "github.com/upfluence/errors/stacktrace"
 
+// StackTrace is a list of frames, innermost call first, see
+// stacktrace.StackTrace.
+type StackTrace = stacktrace.StackTrace
+
+// Frame is a single program counter location of a stack trace, see
+// stacktrace.Frame.
+type Frame = stacktrace.Frame
+
 // WithStack wraps an error with a stack frame captured at the call site.
 func WithStack(err error) error {
 	return WithFrame(err, 1)
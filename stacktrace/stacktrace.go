@@ -7,7 +7,11 @@
 package stacktrace
 
 import (
+	"fmt"
+	"io"
+	"path"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/upfluence/errors/base"
@@ -50,15 +54,57 @@ func (f Frame) Location() (string, string, int) {
 	return fr.Function, fr.File, fr.Line
 }
 
-// GetFrames extracts all stack frames from an error by traversing the error chain.
-func GetFrames(err error) []Frame {
-	var fs []Frame
+// Format formats the frame like github.com/pkg/errors.Frame:
+//
+//	%s    source file
+//	%d    source line
+//	%n    function name
+//	%v    equivalent to %s:%d
+//
+// The + flag prints the function name and the path of the source file:
+//
+//	%+s   function name and path of the source file, separated by "\n\t"
+//	%+v   equivalent to %+s:%d
+func (f Frame) Format(s fmt.State, verb rune) {
+	fn, file, line := f.Location()
 
-	for {
-		if err == nil {
-			break
+	if fn == "" {
+		fn, file = "unknown", "unknown"
+	}
+
+	switch verb {
+	case 's':
+		if s.Flag('+') {
+			io.WriteString(s, fn)
+			io.WriteString(s, "\n\t")
+			io.WriteString(s, file)
+		} else {
+			io.WriteString(s, path.Base(file))
 		}
+	case 'd':
+		io.WriteString(s, strconv.Itoa(line))
+	case 'n':
+		io.WriteString(s, funcName(fn))
+	case 'v':
+		f.Format(s, 's')
+		io.WriteString(s, ":")
+		f.Format(s, 'd')
+	}
+}
+
+// funcName removes the package path from the function name.
+func funcName(name string) string {
+	name = name[strings.LastIndex(name, "/")+1:]
+	return name[strings.Index(name, ".")+1:]
+}
 
+// GetFrames extracts all stack frames from an error by traversing the error
+// chain. Only the first branch of the multi errors is followed, so the frames
+// describe a single call path, see base.InspectPath.
+func GetFrames(err error) []Frame {
+	var fs []Frame
+
+	base.InspectPath(err, func(err error) bool {
 		switch ferr := err.(type) {
 		case interface{ Frame() Frame }:
 			fs = append(fs, ferr.Frame())
@@ -66,12 +112,86 @@ func GetFrames(err error) []Frame {
 			fs = append(fs, ferr.Frames()...)
 		}
 
-		err = base.UnwrapOnce(err)
-	}
+		return true
+	})
 
 	return fs
 }
 
+// StackTrace is a list of frames, innermost call first. It has the shape of
+// github.com/pkg/errors.StackTrace, so the tools reflecting on the
+// StackTrace() method of the pkg/errors errors, sentry-go included, can read
+// the frames of this module.
+type StackTrace []Frame
+
+// Format formats the stack trace like github.com/pkg/errors.StackTrace:
+//
+//	%s    lists the source file of each frame
+//	%v    lists the source file and line of each frame
+//	%+v   prints the function name, path and line of each frame on their
+//	      own lines, see Frame.Format
+func (st StackTrace) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		switch {
+		case s.Flag('+'):
+			for _, f := range st {
+				io.WriteString(s, "\n")
+				f.Format(s, verb)
+			}
+		case s.Flag('#'):
+			fmt.Fprintf(s, "%#v", []Frame(st))
+		default:
+			fmt.Fprintf(s, "%v", []Frame(st))
+		}
+	case 's':
+		fmt.Fprintf(s, "%s", []Frame(st))
+	}
+}
+
+// GetStackTrace returns the frames of the chain of err, innermost call
+// first. The frames attached by a single wrapper keep their order, the
+// wrappers are ordered from the innermost to the outermost.
+func GetStackTrace(err error) StackTrace {
+	var segs [][]Frame
+
+	base.InspectPath(err, func(err error) bool {
+		switch ferr := err.(type) {
+		case interface{ Frame() Frame }:
+			segs = append(segs, []Frame{ferr.Frame()})
+		case interface{ Frames() []Frame }:
+			segs = append(segs, ferr.Frames())
+		}
+
+		return true
+	})
+
+	var st StackTrace
+
+	for i := len(segs) - 1; i >= 0; i-- {
+		st = append(st, segs[i]...)
+	}
+
+	return st
+}
+
+// Origin returns the frame where err originated, that is the innermost frame
+// of its chain. Returns the zero Frame if err carries no frame.
+func Origin(err error) Frame {
+	if fs := GetFrames(err); len(fs) > 0 {
+		return fs[len(fs)-1]
+	}
+
+	return 0
+}
+
+func printFrames(p base.Printer, fs []Frame) {
+	for _, f := range fs {
+		fn, file, line := f.Location()
+		p.Printf("%s\n  %s:%d", fn, file, line)
+	}
+}
+
 // PackageName extracts the package path from a fully qualified function name.
 // Returns an empty string for compiler-generated symbols.
 func PackageName(name string) string {
@@ -0,0 +1,81 @@
+package stacktrace_test
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/stacktrace"
+)
+
+func wrapEOF() error { return errors.WithStack(io.EOF) }
+
+func callerLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func TestGetStackTrace(t *testing.T) {
+	err := errors.Wrap(wrapEOF(), "bar")
+
+	st := err.(interface{ StackTrace() stacktrace.StackTrace }).StackTrace()
+
+	assert.Len(t, st, 2)
+
+	inner, _, _ := st[0].Location()
+	outer, _, _ := st[1].Location()
+
+	assert.Equal(t, "github.com/upfluence/errors/stacktrace_test.wrapEOF", inner)
+	assert.Equal(t, "github.com/upfluence/errors/stacktrace_test.TestGetStackTrace", outer)
+	assert.Nil(t, stacktrace.GetStackTrace(io.EOF))
+}
+
+func TestStackTraceSentry(t *testing.T) {
+	// sentry-go reflects on the StackTrace method of pkg/errors, it reads
+	// the frames of the opaque errors as well
+	err, line := errors.New("foo"), callerLine()
+
+	s := sentry.ExtractStacktrace(err)
+
+	if assert.NotNil(t, s) && assert.Len(t, s.Frames, 1) {
+		assert.Equal(t, "TestStackTraceSentry", s.Frames[0].Function)
+		assert.Equal(t, line, s.Frames[0].Lineno)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	var (
+		err, line     = errors.Wrap(wrapEOF(), "bar"), callerLine()
+		_, file, _, _ = runtime.Caller(0)
+
+		st = err.(interface{ StackTrace() errors.StackTrace }).StackTrace()
+	)
+
+	assert.Equal(t, "stacktrace_test.go", fmt.Sprintf("%s", st[1]))
+	assert.Equal(t, fmt.Sprint(line), fmt.Sprintf("%d", st[1]))
+	assert.Equal(t, "TestFormat", fmt.Sprintf("%n", st[1]))
+	assert.Equal(t, fmt.Sprintf("stacktrace_test.go:%d", line), fmt.Sprintf("%v", st[1]))
+	assert.Equal(
+		t,
+		fmt.Sprintf("github.com/upfluence/errors/stacktrace_test.TestFormat\n\t%s:%d", file, line),
+		fmt.Sprintf("%+v", st[1]),
+	)
+	assert.Equal(
+		t,
+		fmt.Sprintf("[%v %v]", st[0], st[1]),
+		fmt.Sprintf("%v", st),
+	)
+	assert.Equal(
+		t,
+		fmt.Sprintf("\n%+v\n%+v", st[0], st[1]),
+		fmt.Sprintf("%+v", st),
+	)
+	assert.Equal(t, filepath.Base(file), fmt.Sprintf("%s", st[0]))
+	assert.Equal(t, "unknown:0", fmt.Sprintf("%v", errors.Frame(0)))
+}
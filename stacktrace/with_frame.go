@@ -1,5 +1,13 @@
 package stacktrace
 
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/internal/logvalue"
+)
+
 type withFrame struct {
 	cause error
 	frame Frame
@@ -10,6 +18,16 @@ func (wf *withFrame) Unwrap() error { return wf.cause }
 func (wf *withFrame) Cause() error  { return wf.cause }
 func (wf *withFrame) Frame() Frame  { return wf.frame }
 
+func (wf *withFrame) StackTrace() StackTrace { return GetStackTrace(wf) }
+
+func (wf *withFrame) Format(s fmt.State, verb rune) { base.FormatError(wf, s, verb) }
+func (wf *withFrame) LogValue() slog.Value          { return logvalue.Of(wf, Origin(wf)) }
+
+func (wf *withFrame) FormatError(p base.Printer) error {
+	printFrames(p, []Frame{wf.frame})
+	return wf.cause
+}
+
 func WithFrame(err error, depth int) error {
 	if err == nil {
 		return nil
@@ -1,5 +1,13 @@
 package stacktrace
 
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/internal/logvalue"
+)
+
 type withStacktrace struct {
 	cause  error
 	frames []Frame
@@ -10,6 +18,16 @@ func (ws *withStacktrace) Unwrap() error   { return ws.cause }
 func (ws *withStacktrace) Cause() error    { return ws.cause }
 func (ws *withStacktrace) Frames() []Frame { return ws.frames }
 
+func (ws *withStacktrace) StackTrace() StackTrace { return GetStackTrace(ws) }
+
+func (ws *withStacktrace) Format(s fmt.State, verb rune) { base.FormatError(ws, s, verb) }
+func (ws *withStacktrace) LogValue() slog.Value          { return logvalue.Of(ws, Origin(ws)) }
+
+func (ws *withStacktrace) FormatError(p base.Printer) error {
+	printFrames(p, ws.frames)
+	return ws.cause
+}
+
 func WithStacktrace(err error, depth, count int) error {
 	if err == nil {
 		return nil
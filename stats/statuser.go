@@ -7,8 +7,11 @@ package stats
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/message"
+	"github.com/upfluence/errors/registry"
 )
 
 // Statuser provides a custom status string for an error.
@@ -32,8 +35,16 @@ var defaultStatusOptions = extractStatusOptions{
 type defaultStatuser struct{}
 
 func (defaultStatuser) Status(err error) string {
-	switch t := fmt.Sprintf("%T", err); t {
-	case "*errors.errorString", "*errors.fundamental", "*opaque.opaqueError":
+	switch t := fmt.Sprintf("%T", err); {
+	case t == "*errors.errorString", t == "*errors.fundamental":
+		return err.Error()
+	case strings.HasPrefix(strings.TrimPrefix(t, "*"), "opaque."):
+		// every variant of the opaque errors hides its type, the arguments
+		// of Newf may be personal data and are left out of the status
+		if tmpl := message.GetTemplate(err); len(tmpl.Args) > 0 {
+			return tmpl.Format
+		}
+
 		return err.Error()
 	default:
 		return t
@@ -42,13 +53,11 @@ func (defaultStatuser) Status(err error) string {
 
 // GetStatus extracts a status string from an error.
 // Returns the success status string if err is nil.
-// Traverses the error chain looking for a Status() method,
-// falling back to the configured Statuser if none is found.
+// Traverses the error tree looking for a Status() method, see base.Inspect
+// for the precedence rule, then for a registered sentinel code, falling back
+// to the configured Statuser called on the root cause of the first branch,
+// see base.Root, if none is found.
 func GetStatus(err error, opts ...ExtractStatusOption) string {
-	type statuser interface {
-		Status() string
-	}
-
 	var o = defaultStatusOptions
 
 	for _, opt := range opts {
@@ -59,19 +68,42 @@ func GetStatus(err error, opts ...ExtractStatusOption) string {
 		return o.successStatus
 	}
 
-	for {
+	if status, ok := LookupStatus(err); ok {
+		return status
+	}
+
+	return o.fallbackStatuser.Status(base.Root(err))
+}
+
+// LookupStatus returns the status carried by err: the value of the first
+// Status() method of the tree, see base.Inspect for the precedence rule, or
+// else its registered sentinel code. Unlike GetStatus it does not fall back
+// on the type or the message of the error, and returns false instead.
+func LookupStatus(err error) (string, bool) {
+	type statuser interface {
+		Status() string
+	}
+
+	var (
+		status string
+		found  bool
+	)
+
+	base.Inspect(err, func(err error) bool {
 		if st, ok := err.(statuser); ok {
-			return st.Status()
+			status, found = st.Status(), true
 		}
 
-		cause := base.UnwrapOnce(err)
+		return !found
+	})
 
-		if cause == nil {
-			break
-		}
+	if found {
+		return status, true
+	}
 
-		err = cause
+	if code := registry.CodeOf(err); code != "" {
+		return code, true
 	}
 
-	return o.fallbackStatuser.Status(err)
+	return "", false
 }
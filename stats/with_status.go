@@ -1,5 +1,14 @@
 package stats
 
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/internal/logvalue"
+	"github.com/upfluence/errors/stacktrace"
+)
+
 type withStatus struct {
 	cause  error
 	status string
@@ -10,6 +19,14 @@ func (ws *withStatus) Unwrap() error  { return ws.cause }
 func (ws *withStatus) Cause() error   { return ws.cause }
 func (ws *withStatus) Status() string { return ws.status }
 
+func (ws *withStatus) Format(s fmt.State, verb rune) { base.FormatError(ws, s, verb) }
+func (ws *withStatus) LogValue() slog.Value          { return logvalue.Of(ws, stacktrace.Origin(ws)) }
+
+func (ws *withStatus) FormatError(p base.Printer) error {
+	p.Printf("status: %s", ws.status)
+	return ws.cause
+}
+
 func (ws *withStatus) Tags() map[string]interface{} {
 	return map[string]interface{}{"status": ws.status}
 }
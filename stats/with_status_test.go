@@ -1,12 +1,15 @@
 package stats_test
 
 import (
+	"fmt"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/upfluence/errors"
 	"github.com/upfluence/errors/errtest"
+	"github.com/upfluence/errors/multi"
 	"github.com/upfluence/errors/stats"
 	"github.com/upfluence/errors/tags"
 )
@@ -39,15 +42,72 @@ func TestGetStatus(t *testing.T) {
 		"foo",
 		stats.GetStatus(errors.WithStatus(errors.New("bar"), "foo")),
 	)
+
+	assert.Equal(
+		t,
+		"stats_test.registered",
+		stats.GetStatus(errors.Wrap(errRegistered, "wrapped")),
+	)
+
+	assert.Equal(
+		t,
+		"foo",
+		stats.GetStatus(errors.WithStatus(errRegistered, "foo")),
+	)
+
+	assert.Equal(
+		t,
+		"EOF",
+		stats.GetStatus(wrapError{err: multi.Combine(io.EOF, io.ErrUnexpectedEOF)}),
+	)
+
+	assert.Equal(
+		t,
+		"bar",
+		stats.GetStatus(
+			errors.Combine(errors.New("foo"), errors.WithStatus(errors.New("baz"), "bar")),
+		),
+	)
+
+	assert.Equal(
+		t,
+		"foo",
+		stats.GetStatus(fmt.Errorf("%w, %w", errors.New("foo"), errors.New("bar"))),
+	)
+
+	assert.Equal(
+		t,
+		"foo",
+		stats.GetStatus(
+			errors.WithSecondaryError(errors.New("foo"), errors.WithStatus(errors.New("baz"), "bar")),
+		),
+	)
+
+	assert.Equal(
+		t,
+		"user %s not found",
+		stats.GetStatus(errors.Newf("user %s not found", "john@example.com")),
+	)
+	assert.Equal(t, "user not found", stats.GetStatus(errors.Newf("user not found")))
 }
 
+var errRegistered = errors.Register("stats_test.registered", errors.New("registered"))
+
 func TestGetTags(t *testing.T) {
+	err := stats.WithStatus(errors.New("bar"), "baz")
+
 	assert.Equal(
 		t,
 		map[string]interface{}{
-			"domain": "github.com/upfluence/errors/stats_test",
-			"status": "baz",
+			"domain":      "github.com/upfluence/errors/stats_test",
+			"fingerprint": errors.Fingerprint(err),
+			"status":      "baz",
 		},
-		tags.GetTags(stats.WithStatus(errors.New("bar"), "baz")),
+		tags.GetTags(err),
 	)
 }
+
+type wrapError struct{ err error }
+
+func (we wrapError) Error() string { return we.err.Error() }
+func (we wrapError) Unwrap() error { return we.err }
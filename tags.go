@@ -2,7 +2,19 @@ package errors
 
 import "github.com/upfluence/errors/tags"
 
+// WithTag attaches the typed tag v under the key k to the error and adds a
+// stack frame.
+func WithTag[T any](err error, k tags.Key[T], v T) error {
+	return WithFrame(tags.With(err, k, v), 1)
+}
+
 // WithTags attaches key-value tags to the error for additional context and adds a stack frame.
 func WithTags(err error, vs map[string]interface{}) error {
 	return WithFrame(tags.WithTags(err, vs), 1)
 }
+
+// Sensitive marks the tag value v as sensitive, it is redacted from every
+// output of the module but the reporters allowed to send personal data.
+func Sensitive(v interface{}) tags.SensitiveValue {
+	return tags.Sensitive(v)
+}
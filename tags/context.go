@@ -0,0 +1,82 @@
+package tags
+
+import (
+	"context"
+	"sync"
+)
+
+type contextKey struct{}
+
+// ContextExtractor extracts tags from a context. It allows capturing values
+// stored on the context by other libraries, such as tracing identifiers.
+type ContextExtractor func(context.Context) map[string]interface{}
+
+var (
+	extractorsMu sync.RWMutex
+	extractors   []ContextExtractor
+)
+
+// RegisterContextExtractor registers extractors whose tags are returned by
+// FromContext, in addition to the ones attached by NewContext. It is meant to
+// be called at init time.
+func RegisterContextExtractor(fns ...ContextExtractor) {
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+
+	extractors = append(extractors, fns...)
+}
+
+// NewContext returns a copy of ctx carrying vs on top of the tags already
+// carried by ctx. When keys conflict, the values of vs are used.
+func NewContext(ctx context.Context, vs map[string]interface{}) context.Context {
+	if len(vs) == 0 {
+		return ctx
+	}
+
+	parent, _ := ctx.Value(contextKey{}).(map[string]interface{})
+	ts := make(map[string]interface{}, len(parent)+len(vs))
+
+	for k, v := range parent {
+		ts[k] = v
+	}
+
+	for k, v := range vs {
+		ts[k] = v
+	}
+
+	return context.WithValue(ctx, contextKey{}, ts)
+}
+
+// FromContext returns the tags carried by ctx and the ones found by the
+// registered extractors. When keys conflict, the tags attached by NewContext
+// are used.
+// Returns nil if no tags are found.
+func FromContext(ctx context.Context) map[string]interface{} {
+	var ts map[string]interface{}
+
+	extractorsMu.RLock()
+
+	for _, fn := range extractors {
+		for k, v := range fn(ctx) {
+			if ts == nil {
+				ts = make(map[string]interface{})
+			}
+
+			ts[k] = v
+		}
+	}
+
+	extractorsMu.RUnlock()
+
+	vs, _ := ctx.Value(contextKey{}).(map[string]interface{})
+
+	if ts == nil && len(vs) > 0 {
+		ts = make(map[string]interface{}, len(vs))
+	}
+
+	for k, v := range vs {
+		ts[k] = v
+	}
+
+	return ts
+}
@@ -0,0 +1,85 @@
+package tags_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/reporter"
+	"github.com/upfluence/errors/tags"
+)
+
+type traceKey struct{}
+
+func init() {
+	tags.RegisterContextExtractor(
+		reporter.TraceContextExtractor(func(ctx context.Context) (string, string) {
+			traceID, _ := ctx.Value(traceKey{}).(string)
+			return traceID, ""
+		}),
+	)
+}
+
+func TestFromContext(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Nil(t, tags.FromContext(ctx))
+
+	ctx = tags.NewContext(ctx, map[string]interface{}{"user.id": 1, "foo": "bar"})
+	cctx := tags.NewContext(ctx, map[string]interface{}{"user.id": 2})
+
+	assert.Equal(
+		t,
+		map[string]interface{}{"user.id": 1, "foo": "bar"},
+		tags.FromContext(ctx),
+	)
+	assert.Equal(
+		t,
+		map[string]interface{}{"user.id": 2, "foo": "bar"},
+		tags.FromContext(cctx),
+	)
+
+	assert.Equal(
+		t,
+		map[string]interface{}{"user.id": 2, "foo": "bar", "trace.id": "abc"},
+		tags.FromContext(context.WithValue(cctx, traceKey{}, "abc")),
+	)
+}
+
+func TestWrapContext(t *testing.T) {
+	ctx := tags.NewContext(
+		context.Background(),
+		map[string]interface{}{reporter.HTTPRequestPathKey: "/users"},
+	)
+
+	err := errors.WrapContext(ctx, io.EOF, "read body")
+
+	assert.Equal(t, "read body: EOF", err.Error())
+	assert.Equal(
+		t,
+		map[string]interface{}{
+			reporter.HTTPRequestPathKey: "/users",
+			"fingerprint":               errors.Fingerprint(err),
+		},
+		tags.GetTags(err),
+	)
+
+	err = errors.WithContext(ctx, io.EOF)
+
+	assert.Equal(t, io.EOF, errors.Unwrap(errors.Unwrap(err)))
+	assert.Equal(
+		t,
+		map[string]interface{}{
+			reporter.HTTPRequestPathKey: "/users",
+			"fingerprint":               errors.Fingerprint(err),
+		},
+		tags.GetTags(err),
+	)
+
+	assert.Equal(t, io.EOF, errors.Cause(errors.WithContext(context.Background(), io.EOF)))
+	assert.Nil(t, errors.WithContext(ctx, nil))
+	assert.Nil(t, errors.WrapContext(ctx, nil, "foo"))
+}
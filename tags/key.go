@@ -0,0 +1,58 @@
+package tags
+
+import "github.com/upfluence/errors/base"
+
+// Key is a typed tag key. It is stored as a regular tag named after the key,
+// so typed tags are returned by GetTags and untyped tags of the right type
+// are returned by Get.
+type Key[T any] struct {
+	name string
+}
+
+// NewKey returns a typed tag key named name.
+func NewKey[T any](name string) Key[T] { return Key[T]{name: name} }
+
+// Name returns the name of the tag.
+func (k Key[T]) Name() string { return k.name }
+
+// String returns the name of the tag.
+func (k Key[T]) String() string { return k.name }
+
+// Tags returns a tag map holding v under the key, suited for WithTags and
+// NewContext.
+func (k Key[T]) Tags(v T) map[string]interface{} {
+	return map[string]interface{}{k.name: v}
+}
+
+// With attaches the tag v under the key k to the error.
+// Returns nil if err is nil.
+func With[T any](err error, k Key[T], v T) error {
+	return WithTags(err, k.Tags(v))
+}
+
+// Get returns the value of the tag k by traversing the error tree. The
+// outermost value is used, sensitive values are revealed. Returns false if
+// the tag is not found or if its value is not of type T.
+func Get[T any](err error, k Key[T]) (T, bool) {
+	var (
+		v     interface{}
+		found bool
+	)
+
+	base.Inspect(err, func(err error) bool {
+		if t, ok := err.(interface{ Tags() map[string]interface{} }); ok {
+			v, found = t.Tags()[k.name]
+		}
+
+		return !found
+	})
+
+	if found {
+		tv, ok := Reveal(v).(T)
+		return tv, ok
+	}
+
+	var zero T
+
+	return zero, false
+}
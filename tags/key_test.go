@@ -0,0 +1,89 @@
+package tags_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/errtest"
+	"github.com/upfluence/errors/reporter"
+	"github.com/upfluence/errors/tags"
+)
+
+var userID = tags.NewKey[int64]("user.id")
+
+func TestWithTag(t *testing.T) {
+	errtest.TestErrorWrapper(
+		t,
+		func(err error) error { return errors.WithTag(err, userID, 42) },
+		errtest.ErrorWrapperOptions{N: 2},
+	)
+}
+
+func TestGet(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+
+		want   int64
+		wantOk bool
+	}{
+		{name: "nil"},
+		{name: "no tag", err: errors.New("foo")},
+		{name: "typed", err: errors.WithTag(io.EOF, userID, 42), want: 42, wantOk: true},
+		{
+			name:   "outermost",
+			err:    tags.With(errors.WithTag(io.EOF, userID, 42), userID, 43),
+			want:   43,
+			wantOk: true,
+		},
+		{
+			name:   "untyped",
+			err:    errors.WithTags(io.EOF, map[string]interface{}{"user.id": int64(44)}),
+			want:   44,
+			wantOk: true,
+		},
+		{
+			name: "wrong type",
+			err:  errors.WithTags(io.EOF, map[string]interface{}{"user.id": "44"}),
+		},
+		{
+			name:   "opaque",
+			err:    errors.Opaque(errors.WithTag(io.EOF, userID, 45)),
+			want:   45,
+			wantOk: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			v, ok := tags.Get(tt.err, userID)
+
+			assert.Equal(t, tt.want, v)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestKeyInterop(t *testing.T) {
+	err := errors.Combine(
+		errors.WithTag(io.EOF, reporter.HTTPRequestPathTag, "/users"),
+		errors.WithTag(io.ErrUnexpectedEOF, reporter.HTTPRequestHeaderTag("X-Request-Id"), "abc"),
+	)
+
+	assert.Equal(
+		t,
+		map[string]interface{}{
+			reporter.HTTPRequestPathKey:                          "/users",
+			reporter.HTTPRequestHeaderKeyPrefix + "X-Request-Id": "abc",
+			"fingerprint": errors.Fingerprint(err),
+		},
+		tags.GetTags(err),
+	)
+
+	path, ok := tags.Get(err, reporter.HTTPRequestPathTag)
+
+	assert.True(t, ok)
+	assert.Equal(t, "/users", path)
+	assert.Equal(t, reporter.UserIDKey, reporter.UserIDTag.Name())
+}
@@ -0,0 +1,90 @@
+package tags
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/upfluence/errors/internal/sensitive"
+)
+
+// Redacted replaces the sensitive values in every output of the module.
+const Redacted = sensitive.Redacted
+
+// SensitiveValue holds a tag value that must not leak into logs. It renders
+// as Redacted when formatted, encoded as JSON or logged with log/slog. The
+// raw value is only available through Value, reporters decide whether to
+// send it or not.
+type SensitiveValue struct {
+	v interface{}
+}
+
+// Sensitive marks v as sensitive.
+func Sensitive(v interface{}) SensitiveValue {
+	if sv, ok := v.(SensitiveValue); ok {
+		return sv
+	}
+
+	return SensitiveValue{v: v}
+}
+
+// Value returns the raw value.
+func (sv SensitiveValue) Value() interface{} { return sv.v }
+
+func (SensitiveValue) String() string   { return Redacted }
+func (SensitiveValue) GoString() string { return Redacted }
+
+func (SensitiveValue) Format(s fmt.State, verb rune) {
+	fmt.Fprintf(s, fmt.FormatString(s, verb), Redacted)
+}
+
+func (SensitiveValue) MarshalJSON() ([]byte, error) { return json.Marshal(Redacted) }
+func (SensitiveValue) LogValue() slog.Value         { return slog.StringValue(Redacted) }
+
+// Reveal returns the raw value of v if it is a SensitiveValue, v otherwise.
+func Reveal(v interface{}) interface{} {
+	if sv, ok := v.(SensitiveValue); ok {
+		return sv.v
+	}
+
+	return v
+}
+
+// RegisterSensitiveKeys marks every value attached under one of the keys as
+// sensitive. The stored values are left untouched, GetTags still returns
+// them as attached, they are redacted by the outputs of the module and the
+// reporters decide whether to send them, see IsSensitive.
+func RegisterSensitiveKeys(keys ...string) { sensitive.Register(keys...) }
+
+// IsSensitiveKey reports whether k was registered with
+// RegisterSensitiveKeys.
+func IsSensitiveKey(k string) bool { return sensitive.IsKey(k) }
+
+// IsSensitive reports whether the tag value v attached under the key k is
+// sensitive, either marked with Sensitive or attached under a key registered
+// with RegisterSensitiveKeys.
+func IsSensitive(k string, v interface{}) bool {
+	if _, ok := v.(SensitiveValue); ok {
+		return true
+	}
+
+	return IsSensitiveKey(k)
+}
+
+// Mask returns v marked with Sensitive if it is attached under a key
+// registered with RegisterSensitiveKeys, v otherwise. It is used by the
+// outputs rendering tags, so the registered keys are redacted as well.
+func Mask(k string, v interface{}) interface{} {
+	if IsSensitiveKey(k) {
+		return Sensitive(v)
+	}
+
+	return v
+}
+
+// NewSensitiveKey returns a typed tag key whose values are sensitive, see
+// RegisterSensitiveKeys.
+func NewSensitiveKey[T any](name string) Key[T] {
+	RegisterSensitiveKeys(name)
+	return NewKey[T](name)
+}
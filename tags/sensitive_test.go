@@ -0,0 +1,82 @@
+package tags_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/errjson"
+	"github.com/upfluence/errors/errslog"
+	"github.com/upfluence/errors/tags"
+	"github.com/upfluence/errors/wire"
+)
+
+var password = tags.NewSensitiveKey[string]("test.password")
+
+func TestSensitive(t *testing.T) {
+	err := errors.WithTag(
+		errors.WithTags(io.EOF, map[string]interface{}{"token": errors.Sensitive("s3cr3t")}),
+		password,
+		"hunter2",
+	)
+
+	for _, out := range []string{
+		fmt.Sprintf("%v", err),
+		fmt.Sprintf("%+v", err),
+		errslog.Value(err).String(),
+	} {
+		assert.NotContains(t, out, "s3cr3t")
+		assert.NotContains(t, out, "hunter2")
+	}
+
+	// the values of the registered keys are stored untouched
+	assert.NotContains(t, fmt.Sprintf("%v", tags.GetTags(err)), "s3cr3t")
+	assert.Equal(t, "hunter2", tags.GetTags(err)["test.password"])
+	assert.True(t, tags.IsSensitive("test.password", "hunter2"))
+	assert.True(t, tags.IsSensitive("token", tags.Sensitive("s3cr3t")))
+	assert.False(t, tags.IsSensitive("token", "s3cr3t"))
+
+	assert.Contains(t, fmt.Sprintf("%+v", err), "test.password="+tags.Redacted)
+
+	buf, merr := errjson.Marshal(err)
+
+	assert.NoError(t, merr)
+	assert.NotContains(t, string(buf), "s3cr3t")
+	assert.NotContains(t, string(buf), "hunter2")
+
+	// the wire encoding carries the values, the receiving side decides
+	derr := wire.Decode(wire.Encode(err))
+
+	assert.NotContains(t, fmt.Sprintf("%+v", derr), "s3cr3t")
+	assert.NotContains(t, fmt.Sprintf("%+v", derr), "hunter2")
+	assert.True(t, tags.IsSensitive("token", tags.GetTags(derr)["token"]))
+	assert.Equal(t, "s3cr3t", tags.Reveal(tags.GetTags(derr)["token"]))
+	assert.Equal(t, "hunter2", tags.Reveal(tags.GetTags(derr)["test.password"]))
+
+	v, ok := tags.Get(err, password)
+
+	assert.True(t, ok)
+	assert.Equal(t, "hunter2", v)
+	assert.Equal(t, "s3cr3t", tags.Reveal(tags.GetTags(err)["token"]))
+}
+
+func TestSensitiveValue(t *testing.T) {
+	v := tags.Sensitive(42)
+
+	assert.Equal(t, v, tags.Sensitive(v))
+	assert.Equal(t, 42, v.Value())
+	assert.Equal(t, 42, tags.Reveal(v))
+	assert.Equal(t, "foo", tags.Reveal("foo"))
+	assert.Equal(t, "[REDACTED]  ", fmt.Sprintf("%-12v", v))
+	assert.Equal(t, slog.StringValue(tags.Redacted), v.LogValue())
+
+	buf, err := json.Marshal(map[string]interface{}{"v": v})
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"v":"[REDACTED]"}`, string(buf))
+}
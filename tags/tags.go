@@ -2,39 +2,32 @@
 //
 // This package allows errors to carry arbitrary key-value metadata (tags)
 // that can be used for logging, metrics, or error reporting. It traverses
-// the error chain to collect all tags, with outer tags taking precedence
+// the error tree to collect all tags, with outer tags taking precedence
 // over inner tags when keys conflict.
 package tags
 
-import "github.com/upfluence/errors/base"
+import (
+	"github.com/upfluence/errors/fingerprint"
+	"github.com/upfluence/errors/internal/tagset"
+)
 
-// GetTags extracts all tags from an error by traversing the error chain.
-// Returns nil if no tags are found.
-// When multiple errors in the chain have the same tag key, the outermost value is used.
+// GetTags extracts all tags from an error by traversing the error tree.
+// When multiple errors in the tree have the same tag key, the outermost value
+// is used, see base.Inspect for the precedence rule. The fingerprint of the
+// error, see fingerprint.Of, is set under fingerprint.Key.
+// Returns nil if err is nil.
 func GetTags(err error) map[string]interface{} {
-	var tags map[string]interface{}
-
-	for {
-		if err == nil {
-			break
-		}
-
-		if t, ok := err.(interface{ Tags() map[string]interface{} }); ok {
-			ts := t.Tags()
-
-			if len(ts) > 0 && tags == nil {
-				tags = make(map[string]interface{}, len(ts))
-			}
+	if err == nil {
+		return nil
+	}
 
-			for k, v := range ts {
-				if _, ok := tags[k]; !ok {
-					tags[k] = v
-				}
-			}
-		}
+	tags := tagset.Collect(err)
 
-		err = base.UnwrapOnce(err)
+	if tags == nil {
+		tags = make(map[string]interface{}, 1)
 	}
 
+	tags[fingerprint.Key] = fingerprint.Of(err)
+
 	return tags
 }
@@ -1,5 +1,16 @@
 package tags
 
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/internal/logvalue"
+	"github.com/upfluence/errors/stacktrace"
+)
+
 type withTags struct {
 	cause error
 	tags  map[string]interface{}
@@ -13,6 +24,29 @@ func (ws *withTags) Tags() map[string]interface{} {
 	return ws.tags
 }
 
+func (ws *withTags) Format(s fmt.State, verb rune) { base.FormatError(ws, s, verb) }
+func (ws *withTags) LogValue() slog.Value          { return logvalue.Of(ws, stacktrace.Origin(ws)) }
+
+func (ws *withTags) FormatError(p base.Printer) error {
+	ks := make([]string, 0, len(ws.tags))
+
+	for k := range ws.tags {
+		ks = append(ks, k)
+	}
+
+	sort.Strings(ks)
+
+	for i, k := range ks {
+		ks[i] = fmt.Sprintf("%s=%v", k, Mask(k, ws.tags[k]))
+	}
+
+	p.Printf("tags: %s", strings.Join(ks, ", "))
+
+	return ws.cause
+}
+
+// WithTags attaches tags to the error.
+// Returns err if err is nil or if tags is empty.
 func WithTags(err error, tags map[string]interface{}) error {
 	if err == nil || len(tags) == 0 {
 		return err
@@ -1,6 +1,8 @@
 package tags_test
 
 import (
+	"fmt"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,14 +25,31 @@ func TestWithTags(t *testing.T) {
 func TestGetTags(t *testing.T) {
 	assert.Equal(t, 0, len(tags.GetTags(nil)))
 
+	err := errors.WithTags(errors.New("foo"), map[string]interface{}{"foo": 1})
+
 	assert.Equal(
 		t,
 		map[string]interface{}{
-			"domain": "github.com/upfluence/errors/tags_test",
-			"foo":    1,
+			"domain":      "github.com/upfluence/errors/tags_test",
+			"fingerprint": errors.Fingerprint(err),
+			"foo":         1,
 		},
-		tags.GetTags(
-			errors.WithTags(errors.New("foo"), map[string]interface{}{"foo": 1}),
-		),
+		tags.GetTags(err),
+	)
+
+	err = fmt.Errorf(
+		"%w, %w",
+		tags.WithTags(io.EOF, map[string]interface{}{"foo": 1}),
+		tags.WithTags(io.EOF, map[string]interface{}{"foo": 3, "bar": 2}),
 	)
+
+	assert.Equal(
+		t,
+		map[string]interface{}{"foo": 1, "bar": 2, "fingerprint": errors.Fingerprint(err)},
+		tags.GetTags(err),
+	)
+
+	err = errors.WithFingerprint(errors.New("foo"), "checkout", "payment")
+
+	assert.Equal(t, "checkout:payment", tags.GetTags(errors.Opaque(err))["fingerprint"])
 }
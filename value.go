@@ -0,0 +1,16 @@
+package errors
+
+import "github.com/upfluence/errors/value"
+
+// WithValue attaches the value v under key to the error and adds a stack
+// frame. Like with context.WithValue, key should be of an unexported type.
+func WithValue(err error, key, v interface{}) error {
+	return WithFrame(value.WithValue(err, key, v), 1)
+}
+
+// Value returns the value attached under key by traversing the error tree,
+// opaque errors included. Returns false if no value is found or if it is not
+// of type T.
+func Value[T any](err error, key interface{}) (T, bool) {
+	return value.Get[T](err, key)
+}
@@ -0,0 +1,130 @@
+// Package value attaches typed metadata to errors the way context.WithValue
+// attaches it to contexts.
+//
+// It is the extension point of the module: a package defines an unexported
+// key type, attaches values with WithValue and reads them back with Get,
+// without a wrapper type and a traversal of its own. The values are looked
+// up through the opaque errors as well.
+//
+// A package computing its metadata from the error tree with a traversal of
+// its own makes it readable through the opaque errors with a key
+// implementing Resolver: it reads the value of the errors hiding their chain
+// with Own, which resolves the key on their cause.
+package value
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/internal/logvalue"
+	"github.com/upfluence/errors/stacktrace"
+)
+
+type withValue struct {
+	cause error
+	key   interface{}
+	val   interface{}
+}
+
+func (wv *withValue) Error() string { return wv.cause.Error() }
+func (wv *withValue) Unwrap() error { return wv.cause }
+func (wv *withValue) Cause() error  { return wv.cause }
+
+func (wv *withValue) Value(key interface{}) (interface{}, bool) {
+	if wv.key == key {
+		return wv.val, true
+	}
+
+	return nil, false
+}
+
+func (wv *withValue) Format(s fmt.State, verb rune) { base.FormatError(wv, s, verb) }
+func (wv *withValue) LogValue() slog.Value          { return logvalue.Of(wv, stacktrace.Origin(wv)) }
+
+func (wv *withValue) FormatError(p base.Printer) error {
+	p.Printf("value: %T", wv.key)
+	return wv.cause
+}
+
+// WithValue attaches the value v under key to the error. Like with
+// context.WithValue, key must be comparable and should be of an unexported
+// type to avoid collisions between packages.
+// Returns nil if err is nil, panics if key is nil or not comparable.
+func WithValue(err error, key, v interface{}) error {
+	if key == nil {
+		panic("nil key")
+	}
+
+	if !reflect.TypeOf(key).Comparable() {
+		panic("key is not comparable")
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	return &withValue{cause: err, key: key, val: v}
+}
+
+// Lookup returns the value attached under key by traversing the error tree.
+// The outermost value is used, see base.Inspect for the precedence rule.
+// Errors hiding their chain, like opaque errors, expose the values of their
+// cause through Value(key interface{}) (interface{}, bool).
+// The keys implementing Resolver are resolved with their Resolve method
+// instead.
+func Lookup(err error, key interface{}) (interface{}, bool) {
+	if r, ok := key.(Resolver); ok {
+		return r.Resolve(err)
+	}
+
+	var (
+		v     interface{}
+		found bool
+	)
+
+	base.Inspect(err, func(err error) bool {
+		v, found = Own(err, key)
+		return !found
+	})
+
+	return v, found
+}
+
+// Resolver is implemented by the keys whose value is computed from the
+// error tree instead of being attached with WithValue.
+type Resolver interface {
+	// Resolve returns the value of the key for the tree of err.
+	Resolve(err error) (interface{}, bool)
+}
+
+// Own returns the value err itself exposes under key through
+// Value(key interface{}) (interface{}, bool), without traversing its chain.
+// Errors hiding their chain, like opaque errors, expose the value found on
+// their cause, a Resolver key is resolved on their cause.
+func Own(err error, key interface{}) (interface{}, bool) {
+	if verr, ok := err.(interface {
+		Value(interface{}) (interface{}, bool)
+	}); ok {
+		return verr.Value(key)
+	}
+
+	return nil, false
+}
+
+// Get returns the value attached under key, see Lookup. Returns false if
+// no value is found or if it is not of type T.
+func Get[T any](err error, key interface{}) (T, bool) {
+	v, ok := Lookup(err, key)
+
+	if !ok {
+		var zero T
+
+		return zero, false
+	}
+
+	tv, ok := v.(T)
+
+	return tv, ok
+}
@@ -0,0 +1,101 @@
+package value_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/errtest"
+	"github.com/upfluence/errors/value"
+)
+
+type requestIDKey struct{}
+
+type tenantKey int
+
+func TestWithValue(t *testing.T) {
+	errtest.TestErrorWrapper(
+		t,
+		func(err error) error { return errors.WithValue(err, requestIDKey{}, "req-1") },
+		errtest.ErrorWrapperOptions{N: 2},
+	)
+
+	assert.Panics(t, func() { value.WithValue(io.EOF, nil, 1) })
+	assert.Panics(t, func() { value.WithValue(io.EOF, []string{"foo"}, 1) })
+}
+
+func TestGet(t *testing.T) {
+	err := errors.WithValue(
+		errors.Wrap(
+			errors.WithValue(
+				errors.WithValue(io.EOF, requestIDKey{}, "req-inner"),
+				tenantKey(0),
+				int64(42),
+			),
+			"bar",
+		),
+		requestIDKey{},
+		"req-outer",
+	)
+
+	for _, err := range []error{err, errors.Opaque(err)} {
+		id, ok := errors.Value[string](err, requestIDKey{})
+
+		assert.True(t, ok)
+		assert.Equal(t, "req-outer", id)
+
+		tenant, ok := errors.Value[int64](err, tenantKey(0))
+
+		assert.True(t, ok)
+		assert.Equal(t, int64(42), tenant)
+
+		_, ok = errors.Value[int](err, tenantKey(0))
+
+		assert.False(t, ok)
+
+		_, ok = errors.Value[int64](err, tenantKey(1))
+
+		assert.False(t, ok)
+	}
+
+	v, ok := value.Lookup(errors.Combine(io.EOF, value.WithValue(io.EOF, tenantKey(0), 1)), tenantKey(0))
+
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	_, ok = value.Lookup(nil, tenantKey(0))
+
+	assert.False(t, ok)
+}
+
+type depthKey struct{}
+
+func (depthKey) Resolve(err error) (interface{}, bool) {
+	var n int
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		n++
+	}
+
+	return n, n > 0
+}
+
+func TestResolver(t *testing.T) {
+	err := errors.Opaque(errors.Wrap(io.EOF, "foo"))
+
+	n, ok := errors.Value[int](err, depthKey{})
+
+	assert.True(t, ok)
+	assert.Equal(t, 1, n)
+
+	n, ok = errors.Value[int](errors.WithStack(err), depthKey{})
+
+	assert.True(t, ok)
+	assert.Equal(t, 2, n)
+
+	_, ok = value.Own(errors.WithStack(err), depthKey{})
+
+	assert.False(t, ok)
+}
@@ -0,0 +1,26 @@
+package errors
+
+import "github.com/upfluence/errors/base"
+
+// Path is the list of steps leading from the root of an error tree to one of
+// its nodes, see base.Path.
+type Path = base.Path
+
+// WalkAction tells Walk how to proceed after visiting a node.
+type WalkAction = base.WalkAction
+
+const (
+	// WalkContinue visits the children of the node, then its siblings.
+	WalkContinue = base.WalkContinue
+
+	// WalkSkip does not visit the children of the node.
+	WalkSkip = base.WalkSkip
+
+	// WalkStop ends the walk.
+	WalkStop = base.WalkStop
+)
+
+// Walk visits every node of the tree of err, following the error chains, the
+// branches of the multi errors and the secondary errors. Parents are visited
+// before their children, see base.Walk for the exact order.
+func Walk(err error, fn func(error, Path) WalkAction) { base.Walk(err, fn) }
@@ -0,0 +1,23 @@
+//go:build go1.23
+
+package errors
+
+import (
+	"iter"
+
+	"github.com/upfluence/errors/base"
+)
+
+// All returns an iterator over every node of the tree of err and its path,
+// in the order of Walk.
+func All(err error) iter.Seq2[Path, error] {
+	return func(yield func(Path, error) bool) {
+		base.Walk(err, func(err error, p Path) WalkAction {
+			if !yield(p, err) {
+				return WalkStop
+			}
+
+			return WalkContinue
+		})
+	}
+}
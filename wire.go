@@ -0,0 +1,13 @@
+package errors
+
+import "github.com/upfluence/errors/wire"
+
+// Encode returns a compact encoding of err meant to cross process boundaries.
+// Returns nil if err is nil.
+func Encode(err error) []byte { return wire.Encode(err) }
+
+// Decode decodes an error encoded by Encode. Sentinels registered with
+// Register decode to the very same value so Is keeps working on the decoded
+// error.
+// Returns nil if buf is empty.
+func Decode(buf []byte) error { return wire.Decode(buf) }
@@ -0,0 +1,193 @@
+package wire
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/upfluence/errors/base"
+	"github.com/upfluence/errors/domain"
+	"github.com/upfluence/errors/internal/tree"
+	"github.com/upfluence/errors/message"
+	"github.com/upfluence/errors/multi"
+	"github.com/upfluence/errors/registry"
+	"github.com/upfluence/errors/secondary"
+	"github.com/upfluence/errors/stats"
+	"github.com/upfluence/errors/tags"
+)
+
+// ErrInvalidEncoding is wrapped by the error returned by Decode when its
+// input is not a valid encoding.
+var ErrInvalidEncoding = errors.New("wire: invalid encoding")
+
+type remoteError struct {
+	cause error
+
+	typ  string
+	text string
+}
+
+func (re *remoteError) Error() string      { return re.text }
+func (re *remoteError) Unwrap() error      { return re.cause }
+func (re *remoteError) TypeName() string   { return re.typ }
+func (re *remoteError) RemoteText() string { return re.text }
+
+func (re *remoteError) Format(s fmt.State, verb rune) { base.FormatError(re, s, verb) }
+
+func (re *remoteError) FormatError(p base.Printer) error {
+	p.Printf("%s (%s)", re.text, re.typ)
+	return re.cause
+}
+
+type withFrames struct {
+	cause  error
+	frames []tree.Frame
+}
+
+func (wf *withFrames) Error() string              { return wf.cause.Error() }
+func (wf *withFrames) Unwrap() error              { return wf.cause }
+func (wf *withFrames) Cause() error               { return wf.cause }
+func (wf *withFrames) RemoteFrames() []tree.Frame { return wf.frames }
+
+func (wf *withFrames) Format(s fmt.State, verb rune) { base.FormatError(wf, s, verb) }
+
+func (wf *withFrames) FormatError(p base.Printer) error {
+	for _, f := range wf.frames {
+		p.Printf("%s\n  %s:%d", f.Function, f.File, f.Line)
+	}
+
+	return wf.cause
+}
+
+// Decode decodes an error encoded by Encode. Sentinels registered in the
+// registry package, and the common sentinels of the standard library, decode
+// to the very same value, root causes of other types decode to a placeholder
+// exposing the original type through its TypeName method.
+// Returns nil if buf is empty, and an error wrapping ErrInvalidEncoding if
+// buf is not a valid encoding.
+func Decode(buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+
+	var (
+		e envelope
+
+		dec = json.NewDecoder(bytes.NewReader(buf))
+	)
+
+	dec.UseNumber()
+
+	if err := dec.Decode(&e); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEncoding, err)
+	}
+
+	if e.Version != version {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, e.Version)
+	}
+
+	return decodeChain(e.Chain)
+}
+
+func decodeChain(ns []node) error {
+	var err error
+
+	for i := len(ns) - 1; i >= 0; i-- {
+		err = decodeNode(ns[i], err)
+	}
+
+	return err
+}
+
+func decodeNode(n node, cause error) error {
+	if n.Code != "" {
+		if err := registry.ByCode(n.Code); err != nil {
+			return err
+		}
+	}
+
+	if cause == nil && n.Message == nil {
+		if err, ok := stdlibSentinels[sentinelIdentifier(n.Type, n.Text)]; ok {
+			return err
+		}
+	}
+
+	var err = cause
+
+	switch {
+	case len(n.Errors) > 0:
+		errs := make([]error, len(n.Errors))
+
+		for i, ns := range n.Errors {
+			errs[i] = decodeChain(ns)
+		}
+
+		err = multi.Wrap(errs)
+	case len(n.Secondary) > 0:
+		err = secondary.WithSecondaryError(cause, decodeChain(n.Secondary))
+	case n.Message != nil:
+		err = message.WithMessage(cause, *n.Message)
+	case n.Text != "" || cause == nil:
+		err = &remoteError{cause: cause, typ: n.Type, text: n.Text}
+	}
+
+	if len(n.Frames) > 0 {
+		fs := make([]tree.Frame, len(n.Frames))
+
+		for i, f := range n.Frames {
+			fs[i] = tree.Frame{Function: f.Function, File: f.File, Line: f.Line}
+		}
+
+		err = &withFrames{cause: err, frames: fs}
+	}
+
+	if n.Domain != "" {
+		err = domain.WithDomain(err, domain.Domain(n.Domain))
+	}
+
+	if n.Status != "" {
+		err = stats.WithStatus(err, n.Status)
+	}
+
+	ts := decodeTags(n.Tags)
+
+	for _, k := range n.Sensitive {
+		if v, ok := ts[k]; ok {
+			ts[k] = tags.Sensitive(v)
+		}
+	}
+
+	return tags.WithTags(err, ts)
+}
+
+func decodeTags(ts map[string]interface{}) map[string]interface{} {
+	for k, v := range ts {
+		ts[k] = decodeValue(v)
+	}
+
+	return ts
+}
+
+// decodeValue converts the numbers decoded as json.Number to int64 when
+// they are integers, float64 otherwise.
+func decodeValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+
+		f, _ := v.Float64()
+
+		return f
+	case map[string]interface{}:
+		return decodeTags(v)
+	case []interface{}:
+		for i, vv := range v {
+			v[i] = decodeValue(vv)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,30 @@
+package wire
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+// stdlibSentinels are the sentinels of the standard library decoding back to
+// themselves, identified by their type and message. The sentinels of the
+// applications are identified by their code, see the registry package.
+var stdlibSentinels = map[string]error{}
+
+func init() {
+	for _, err := range []error{
+		io.EOF,
+		io.ErrUnexpectedEOF,
+		io.ErrClosedPipe,
+		context.Canceled,
+		context.DeadlineExceeded,
+		os.ErrNotExist,
+		os.ErrExist,
+		os.ErrPermission,
+	} {
+		stdlibSentinels[sentinelIdentifier(fmt.Sprintf("%T", err), err.Error())] = err
+	}
+}
+
+func sentinelIdentifier(typ, text string) string { return typ + ":" + text }
@@ -0,0 +1,109 @@
+// Package wire provides a compact encoding of errors meant to cross process
+// boundaries.
+//
+// The encoding carries the messages, tags, domain, status, stack frames
+// (symbolized at encoding time), multi error branches and secondary errors
+// of the chain. Sentinel errors registered in the registry package on both
+// sides of the boundary, as well as the common sentinels of the standard
+// library, decode back to the very same value, so errors.Is keeps working
+// on the receiving side. Errors of unknown types decode to a placeholder
+// keeping the original message and type name.
+//
+// Tag values go through JSON: numbers decode as int64 when they are
+// integers and as float64 otherwise, the other values decode as their JSON
+// representation. Sensitive values, see tags.IsSensitive, are encoded as is
+// and decode marked with tags.Sensitive: the receiving side decides whether
+// to reveal them, the encoding must only cross trusted boundaries.
+package wire
+
+import (
+	"encoding/json"
+
+	"github.com/upfluence/errors/internal/tree"
+)
+
+const version = 1
+
+type envelope struct {
+	Version int    `json:"v"`
+	Chain   []node `json:"c"`
+}
+
+type node struct {
+	Type string `json:"t"`
+	Code string `json:"k,omitempty"`
+
+	// Message is the message added by a wrapper layer, possibly empty, Text
+	// is the full message of a root cause or of a foreign wrapper.
+	Message *string `json:"m,omitempty"`
+	Text    string  `json:"x,omitempty"`
+
+	Frames []frame                `json:"f,omitempty"`
+	Tags   map[string]interface{} `json:"g,omitempty"`
+	Domain string                 `json:"d,omitempty"`
+	Status string                 `json:"s,omitempty"`
+
+	// Sensitive lists the keys of the sensitive tags.
+	Sensitive []string `json:"r,omitempty"`
+
+	Errors    [][]node `json:"e,omitempty"`
+	Secondary []node   `json:"2,omitempty"`
+}
+
+type frame struct {
+	Function string `json:"n"`
+	File     string `json:"p"`
+	Line     int    `json:"l"`
+}
+
+// Encode returns the encoding of err.
+// Returns nil if err is nil.
+func Encode(err error) []byte {
+	if err == nil {
+		return nil
+	}
+
+	buf, merr := json.Marshal(envelope{Version: version, Chain: fromTree(tree.Encode(err))})
+
+	if merr != nil {
+		// the tags are sanitized while encoded, this should never happen
+		panic(merr)
+	}
+
+	return buf
+}
+
+func fromTree(tns []tree.Node) []node {
+	if tns == nil {
+		return nil
+	}
+
+	ns := make([]node, len(tns))
+
+	for i, tn := range tns {
+		n := node{
+			Type:    tn.Type,
+			Code:    tn.Code,
+			Message: tn.Message,
+			Text:    tn.Text,
+			Tags:    tn.Tags,
+			Domain:  tn.Domain,
+			Status:  tn.Status,
+
+			Sensitive: tn.Sensitive,
+			Secondary: fromTree(tn.Secondary),
+		}
+
+		for _, f := range tn.Frames {
+			n.Frames = append(n.Frames, frame{Function: f.Function, File: f.File, Line: f.Line})
+		}
+
+		for _, b := range tn.Errors {
+			n.Errors = append(n.Errors, fromTree(b))
+		}
+
+		ns[i] = n
+	}
+
+	return ns
+}
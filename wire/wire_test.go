@@ -0,0 +1,142 @@
+package wire_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors"
+	"github.com/upfluence/errors/domain"
+	"github.com/upfluence/errors/multi"
+	"github.com/upfluence/errors/stats"
+	"github.com/upfluence/errors/tags"
+	"github.com/upfluence/errors/wire"
+)
+
+var (
+	errSentinel = errors.Register("wire_test.sentinel", errors.New("sentinel"))
+	errCoded    = errors.Register("wire_test.coded", errors.New("coded"))
+)
+
+func TestEncodeDecode(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+
+		sentinels []error
+		fn        func(*testing.T, error)
+	}{
+		{name: "nil"},
+		{name: "stdlib sentinel", err: io.EOF, sentinels: []error{io.EOF}},
+		{
+			name:      "wrapped sentinel",
+			err:       errors.Wrapf(errSentinel, "fetch %d", 42),
+			sentinels: []error{errSentinel},
+			fn: func(t *testing.T, err error) {
+				assert.Equal(t, errSentinel, errors.Cause(err))
+			},
+		},
+		{
+			name:      "registered code",
+			err:       errors.WithStack(errCoded),
+			sentinels: []error{errCoded},
+			fn: func(t *testing.T, err error) {
+				assert.Equal(t, "wire_test.coded", errors.CodeOf(err))
+			},
+		},
+		{
+			name:      "multi",
+			err:       errors.Combine(context.Canceled, fmt.Errorf("timeout: %w", context.DeadlineExceeded)),
+			sentinels: []error{context.Canceled, context.DeadlineExceeded},
+			fn: func(t *testing.T, err error) {
+				assert.Len(t, multi.ExtractErrors(err), 2)
+			},
+		},
+		{
+			name:      "empty message",
+			err:       errors.WithMessage(context.Canceled, ""),
+			sentinels: []error{context.Canceled},
+			fn: func(t *testing.T, err error) {
+				assert.Equal(t, ": context canceled", err.Error())
+			},
+		},
+		{
+			name: "unregistered sentinel",
+			err:  errors.Wrap(errors.New("sentinel"), "fetch"),
+			fn: func(t *testing.T, err error) {
+				assert.False(t, errors.Is(err, errSentinel))
+			},
+		},
+		{
+			name:      "secondary",
+			err:       errors.WithSecondaryError(io.ErrUnexpectedEOF, errors.Wrap(io.EOF, "close")),
+			sentinels: []error{io.ErrUnexpectedEOF},
+		},
+		{
+			name: "metadata",
+			err: errors.WithStatus(
+				errors.WithTags(
+					errors.New("unknown"),
+					map[string]interface{}{"user.id": 42, "ratio": 0.5},
+				),
+				"failed",
+			),
+			fn: func(t *testing.T, err error) {
+				assert.Equal(t, "failed", stats.GetStatus(err))
+				assert.Equal(
+					t,
+					domain.Domain("github.com/upfluence/errors/wire_test"),
+					domain.GetDomain(err),
+				)
+				assert.Equal(
+					t,
+					map[string]interface{}{
+						"domain":      "github.com/upfluence/errors/wire_test",
+						"fingerprint": errors.Fingerprint(err),
+						"status":      "failed",
+						"user.id":     int64(42),
+						"ratio":       0.5,
+					},
+					tags.GetTags(err),
+				)
+
+				cause, ok := errors.Cause(err).(interface{ TypeName() string })
+
+				assert.True(t, ok)
+				assert.Equal(t, "*opaque.opaqueError", cause.TypeName())
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := wire.Encode(tt.err)
+			err := wire.Decode(buf)
+
+			if tt.err == nil {
+				assert.Nil(t, buf)
+				assert.Nil(t, err)
+				return
+			}
+
+			assert.Equal(t, tt.err.Error(), err.Error())
+
+			for _, s := range tt.sentinels {
+				assert.True(t, errors.Is(err, s))
+			}
+
+			assert.Equal(t, tt.err.Error(), wire.Decode(wire.Encode(err)).Error())
+
+			if tt.fn != nil {
+				tt.fn(t, err)
+			}
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for _, buf := range []string{"foo", `{"v":2}`} {
+		assert.True(t, errors.Is(wire.Decode([]byte(buf)), wire.ErrInvalidEncoding))
+	}
+}
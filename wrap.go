@@ -3,6 +3,7 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/upfluence/errors/domain"
 	"github.com/upfluence/errors/message"
@@ -22,15 +23,49 @@ func New(msg string) error {
 
 // Newf creates a new error with a formatted message. The error is wrapped with a
 // stack frame, domain derived from the calling package, and made opaque.
+// If the message wraps errors with the %w verb, the error is not made opaque
+// and behaves like Errorf.
 func Newf(msg string, args ...interface{}) error {
+	if strings.Contains(msg, "%w") {
+		ferr := fmt.Errorf(msg, args...)
+
+		switch ferr.(type) {
+		case interface{ Unwrap() error }, interface{ Unwrap() []error }:
+			return domain.WithDomain(WithFrame(ferr, 1), domain.PackageDomainAtDepth(1))
+		}
+	}
+
 	return opaque.Opaque(
 		domain.WithDomain(
-			WithFrame(fmt.Errorf(msg, args...), 1),
+			WithFrame(message.Newf(msg, args...), 1),
 			domain.PackageDomainAtDepth(1),
 		),
 	)
 }
 
+// Errorf creates a new error with a formatted message like fmt.Errorf: every
+// error wrapped with the %w verb stays reachable through Unwrap, errors.Is and
+// errors.As. The error is wrapped with a stack frame and domain derived from
+// the calling package.
+func Errorf(msg string, args ...interface{}) error {
+	return domain.WithDomain(
+		WithFrame(fmt.Errorf(msg, args...), 1),
+		domain.PackageDomainAtDepth(1),
+	)
+}
+
+// WithMessage wraps an error with an additional message. Unlike Wrap, no
+// stack frame is added, like github.com/pkg/errors.WithMessage.
+func WithMessage(err error, msg string) error {
+	return message.WithMessage(err, msg)
+}
+
+// WithMessagef wraps an error with a formatted message. Unlike Wrapf, no
+// stack frame is added, like github.com/pkg/errors.WithMessagef.
+func WithMessagef(err error, msg string, args ...interface{}) error {
+	return message.WithMessagef(err, msg, args...)
+}
+
 // Wrap wraps an error with an additional message and stack frame.
 func Wrap(err error, msg string) error {
 	return WithFrame(message.WithMessage(err, msg), 1)
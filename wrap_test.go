@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/upfluence/errors/domain"
+	"github.com/upfluence/errors/stacktrace"
+)
+
+func TestErrorf(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		fn   func(string, ...interface{}) error
+	}{
+		{name: "Errorf", fn: Errorf},
+		{name: "Newf", fn: Newf},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fn("loading %s: %w", "foo", io.EOF)
+
+			assert.Equal(t, "loading foo: EOF", err.Error())
+			assert.ErrorIs(t, err, io.EOF)
+			assert.Equal(t, domain.Domain("github.com/upfluence/errors"), domain.GetDomain(err))
+
+			fn, _, _ := stacktrace.GetFrames(err)[0].Location()
+
+			assert.Equal(t, "github.com/upfluence/errors.TestErrorf.func1", fn)
+
+			err = tt.fn("%w and %w", io.EOF, io.ErrClosedPipe)
+
+			assert.ErrorIs(t, err, io.EOF)
+			assert.ErrorIs(t, err, io.ErrClosedPipe)
+		})
+	}
+
+	assert.Equal(t, "*domain.withDomain", typeName(Errorf("foo %d", 1)))
+	assert.Equal(t, "*opaque.opaqueError", typeName(Newf("foo %d", 1)))
+	assert.NotErrorIs(t, Newf("foo %v", io.EOF), io.EOF)
+}
+
+func typeName(err error) string { return fmt.Sprintf("%T", err) }
+
+func TestWithMessage(t *testing.T) {
+	assert.Nil(t, WithMessage(nil, "foo"))
+	assert.Nil(t, WithMessagef(nil, "foo %d", 1))
+
+	err := WithMessagef(WithMessage(io.EOF, "bar"), "foo %d", 42)
+
+	assert.Equal(t, "foo 42: bar: EOF", err.Error())
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Empty(t, stacktrace.GetFrames(err))
+}